@@ -1,13 +1,25 @@
 package main
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/leonc/ficc-pricer/market-gateway/internal/client"
 	"github.com/leonc/ficc-pricer/market-gateway/internal/config"
+	"github.com/leonc/ficc-pricer/market-gateway/internal/dsl"
+	"github.com/leonc/ficc-pricer/market-gateway/internal/market"
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
 )
 
 var (
@@ -46,15 +58,93 @@ It handles:
 
 // priceCmd represents the price command
 var priceCmd = &cobra.Command{
-	Use:   "price",
+	Use:   "price [expression]",
 	Short: "Request a price for an FX contract",
 	Long: `Send a pricing request to the Haskell pricing service.
 
+The contract is either a DSL expression (see internal/dsl) or built from
+the --contract flags. Market data is given with --spot; discount curves and
+volatilities default to the configured flat rate and volatility.
+
 Example:
-  market-gateway price --contract option --strike 1.15 --maturity 2025-12-31`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("price command called (not yet implemented)")
-		fmt.Println("Price command - to be implemented with protobuf generation")
+  market-gateway price --spot EUR/USD=1.10 \
+    'scale 1e6 (call EUR/USD K=1.15 T=2025-12-31) + scale -1e6 (put EUR/USD K=1.05 T=2025-12-31)'
+  market-gateway price --spot EUR/USD=1.10 --contract option --strike 1.15 --maturity 2025-12-31`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var contract models.Contract
+		var err error
+		if len(args) == 1 {
+			contract, err = dsl.Parse(args[0])
+		} else {
+			contract, err = contractFromFlags(cmd)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid contract: %w", err)
+		}
+
+		valuationDate := time.Now()
+		if s, _ := cmd.Flags().GetString("valuation-date"); s != "" {
+			if valuationDate, err = time.Parse("2006-01-02", s); err != nil {
+				return fmt.Errorf("invalid valuation date %q: %w", s, err)
+			}
+		}
+		if err := models.Validate(contract, valuationDate); err != nil {
+			return err
+		}
+
+		numeraireName, _ := cmd.Flags().GetString("numeraire")
+		if numeraireName == "" {
+			numeraireName = config.GetConfig().Market.DefaultCurrency
+		}
+		numeraireCurrency, err := models.ParseCurrency(numeraireName)
+		if err != nil {
+			return fmt.Errorf("invalid numeraire: %w", err)
+		}
+		numeraire, err := client.CurrencyToProto(numeraireCurrency)
+		if err != nil {
+			return fmt.Errorf("invalid numeraire: %w", err)
+		}
+
+		deps, err := market.AnalyzeDependencies(contract)
+		if err != nil {
+			return err
+		}
+		spots, _ := cmd.Flags().GetStringArray("spot")
+		snapshot, err := snapshotFromFlags(spots, deps)
+		if err != nil {
+			return err
+		}
+		if err := deps.Check(snapshot); err != nil {
+			return err
+		}
+		// Reject currencies the pricing service does not know before connecting
+		if _, err := client.ContractToProto(contract); err != nil {
+			return err
+		}
+
+		logger.Info("pricing contract", zap.String("contract", dsl.Format(contract)))
+
+		pricerClient, err := connectClient(cmd)
+		if err != nil {
+			return err
+		}
+		defer pricerClient.Close()
+
+		ctx, cancel := requestContext()
+		defer cancel()
+
+		params := client.NewPricingParams(valuationDate, numeraire, pb.PricingModel_BLACK_SCHOLES)
+		resp, err := pricerClient.PriceRequest(ctx, contract, snapshot, params)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Price: %.2f %s\n", resp.Price, resp.Numeraire)
+		for _, component := range resp.GetBreakdown().GetComponents() {
+			fmt.Printf("  %s: %.2f\n", component.Description, component.Price)
+		}
+		return nil
 	},
 }
 
@@ -66,10 +156,89 @@ var updateCmd = &cobra.Command{
 Supports spot rates, discount curves, and volatility surfaces.
 
 Example:
-  market-gateway update --spot EUR/USD=1.1050`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("update command called (not yet implemented)")
-		fmt.Println("Update command - to be implemented with protobuf generation")
+  market-gateway update --spot EUR/USD=1.1050
+  market-gateway update --spot EUR/USD=1.1049/1.1051 --source EBS --sequence 42`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spot, _ := cmd.Flags().GetString("spot")
+		if spot == "" {
+			return fmt.Errorf("no market data update given (use --spot)")
+		}
+
+		quote, err := parseSpotFlag(spot)
+		if err != nil {
+			return err
+		}
+		currencyPair, err := models.ParseCurrencyPair(strings.ToUpper(quote.pair))
+		if err != nil {
+			return err
+		}
+		source, _ := cmd.Flags().GetString("source")
+		sequence, _ := cmd.Flags().GetUint64("sequence")
+
+		pricerClient, err := connectClient(cmd)
+		if err != nil {
+			return err
+		}
+		defer pricerClient.Close()
+
+		ctx, cancel := requestContext()
+		defer cancel()
+
+		ack, err := pricerClient.UpdateMarket(ctx, &pb.MarketUpdate{
+			UpdateType: &pb.MarketUpdate_SpotUpdate{SpotUpdate: &pb.SpotUpdate{
+				Pair:     currencyPair.String(),
+				Rate:     quote.rate,
+				Bid:      quote.bid,
+				Ask:      quote.ask,
+				Source:   source,
+				Sequence: sequence,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(ack.Message)
+		return nil
+	},
+}
+
+// cashflowsCmd represents the cashflow export command
+var cashflowsCmd = &cobra.Command{
+	Use:   "cashflows <expression>",
+	Short: "Export the cashflow schedule of a contract",
+	Long: `List the payments a contract makes per currency and date, as CSV or JSON.
+Option legs and legs guarded by a condition are marked contingent.
+
+Example:
+  market-gateway cashflows --format csv \
+    'scale 1e6 (forward EUR/USD F=1.12 T=2025-12-31) + scale 1e6 (call EUR/USD K=1.15 T=2025-12-31)'`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contract, err := dsl.Parse(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid contract: %w", err)
+		}
+		if err := models.Validate(contract, time.Time{}); err != nil {
+			return err
+		}
+
+		flows, err := models.Cashflows(contract)
+		if err != nil {
+			return err
+		}
+		if aggregate, _ := cmd.Flags().GetBool("aggregate"); aggregate {
+			flows = models.AggregateCashflows(flows)
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		switch format {
+		case "csv":
+			return writeCashflowsCSV(os.Stdout, flows)
+		case "json":
+			return writeCashflowsJSON(os.Stdout, flows)
+		}
+		return fmt.Errorf("unknown format %q: expected csv or json", format)
 	},
 }
 
@@ -90,9 +259,26 @@ var healthCmd = &cobra.Command{
 	Use:   "health",
 	Short: "Check pricing service health",
 	Long:  `Query the health status of the Haskell pricing service.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		logger.Info("health command called (not yet implemented)")
-		fmt.Println("Health command - to be implemented with protobuf generation")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pricerClient, err := connectClient(cmd)
+		if err != nil {
+			return err
+		}
+		defer pricerClient.Close()
+
+		ctx, cancel := requestContext()
+		defer cancel()
+
+		status, err := pricerClient.HealthCheck(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Healthy: %t\n", status.Healthy)
+		fmt.Printf("Version: %s\n", status.Version)
+		fmt.Printf("Uptime: %ds\n", status.UptimeSeconds)
+		fmt.Printf("Requests processed: %d\n", status.RequestsProcessed)
+		return nil
 	},
 }
 
@@ -108,24 +294,306 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(serveCmd)
 	rootCmd.AddCommand(healthCmd)
+	rootCmd.AddCommand(cashflowsCmd)
 
-	// Price command flags (placeholders)
-	priceCmd.Flags().String("contract", "option", "contract type (spot, forward, option)")
-	priceCmd.Flags().Float64("strike", 0, "option strike price")
+	// Price command flags
+	priceCmd.Flags().String("contract", "option", "contract type when no expression is given (spot, forward, option)")
+	priceCmd.Flags().String("option-type", "call", "option type (call, put)")
+	priceCmd.Flags().String("pair", "EUR/USD", "currency pair as FOREIGN/DOMESTIC")
+	priceCmd.Flags().Float64("strike", 0, "option strike price, or the fixed rate of a forward")
 	priceCmd.Flags().String("maturity", "", "contract maturity date (ISO 8601)")
+	priceCmd.Flags().StringArray("spot", nil, "spot rate (format: CCY1/CCY2=rate or CCY1/CCY2=bid/ask, repeatable)")
+	priceCmd.Flags().String("valuation-date", "", "valuation date (ISO 8601, default today)")
+	priceCmd.Flags().String("numeraire", "", "numeraire currency (default from config)")
+
+	// Cashflows command flags
+	cashflowsCmd.Flags().String("format", "csv", "output format (csv, json)")
+	cashflowsCmd.Flags().Bool("aggregate", false, "net flows per date, currency and contingency")
 
-	// Update command flags (placeholders)
-	updateCmd.Flags().String("spot", "", "spot rate update (format: CCY1/CCY2=rate)")
+	// Update command flags
+	updateCmd.Flags().String("spot", "", "spot rate update (format: CCY1/CCY2=rate or CCY1/CCY2=bid/ask)")
+	updateCmd.Flags().String("source", "", "quote source, e.g. EBS")
+	updateCmd.Flags().Uint64("sequence", 0, "per-source quote sequence number")
 }
 
 func initConfig() {
 	if cfgFile != "" {
 		// Use config file from the flag
-		config.LoadConfig(cfgFile)
+		if err := config.LoadConfig(cfgFile); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 	} else {
 		// Use default config locations
 		config.LoadDefaultConfig()
 	}
+
+	if err := loadCurrencies(config.GetConfig().Currencies); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid currency configuration: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadCurrencies registers configured currencies alongside the built-in ones
+func loadCurrencies(currencies []config.CurrencyConfig) error {
+	infos := make([]models.CurrencyInfo, len(currencies))
+	for i, c := range currencies {
+		infos[i] = models.CurrencyInfo{
+			Code:           strings.ToUpper(c.Code),
+			Numeric:        c.Numeric,
+			MinorUnits:     c.MinorUnits,
+			SettlementDays: c.SettlementDays,
+			Calendar:       c.Calendar,
+		}
+	}
+	return models.LoadCurrencies(infos)
+}
+
+// connectClient creates a pricer client for the --server address and connects it
+func connectClient(cmd *cobra.Command) (*client.PricerClient, error) {
+	addr, _ := cmd.Flags().GetString("server")
+
+	pricerClient, err := client.NewPricerClient(addr, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pricerClient.Connect(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return pricerClient, nil
+}
+
+// requestContext returns a context bounded by the configured request timeout
+func requestContext() (context.Context, context.CancelFunc) {
+	timeout := time.Duration(config.GetConfig().Server.RequestTimeout) * time.Second
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// spotFlag is a parsed --spot value: a mid rate or a bid/ask quote
+type spotFlag struct {
+	pair string
+	rate float64 // mid; the average of bid and ask for two-way quotes
+	bid  float64 // zero for one-way quotes
+	ask  float64
+}
+
+// parseSpotFlag parses a spot of the form CCY1/CCY2=rate or CCY1/CCY2=bid/ask
+func parseSpotFlag(s string) (spotFlag, error) {
+	pair, value, found := strings.Cut(s, "=")
+	if !found || pair == "" {
+		return spotFlag{}, fmt.Errorf("invalid spot update %q: expected CCY1/CCY2=rate or CCY1/CCY2=bid/ask", s)
+	}
+
+	bidText, askText, twoWay := strings.Cut(value, "/")
+	if !twoWay {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return spotFlag{}, fmt.Errorf("invalid spot rate in %q: %w", s, err)
+		}
+		return spotFlag{pair: pair, rate: rate}, nil
+	}
+
+	bid, err := strconv.ParseFloat(bidText, 64)
+	if err != nil {
+		return spotFlag{}, fmt.Errorf("invalid bid in %q: %w", s, err)
+	}
+	ask, err := strconv.ParseFloat(askText, 64)
+	if err != nil {
+		return spotFlag{}, fmt.Errorf("invalid ask in %q: %w", s, err)
+	}
+	if err := market.ValidateQuote(bid, ask); err != nil {
+		return spotFlag{}, fmt.Errorf("invalid spot update %q: %w", s, err)
+	}
+
+	return spotFlag{pair: pair, rate: (bid + ask) / 2, bid: bid, ask: ask}, nil
+}
+
+// parsePair parses FOREIGN/DOMESTIC and returns (domestic, foreign)
+func parsePair(s string) (models.Currency, models.Currency, error) {
+	pair, err := models.ParseCurrencyPair(strings.ToUpper(s))
+	if err != nil {
+		return 0, 0, err
+	}
+	return pair.Quote, pair.Base, nil
+}
+
+// contractFromFlags builds a single contract from the --contract flags
+func contractFromFlags(cmd *cobra.Command) (models.Contract, error) {
+	kind, _ := cmd.Flags().GetString("contract")
+	pair, _ := cmd.Flags().GetString("pair")
+	strike, _ := cmd.Flags().GetFloat64("strike")
+	maturityFlag, _ := cmd.Flags().GetString("maturity")
+
+	domestic, foreign, err := parsePair(pair)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "spot" {
+		return models.NewSpot(domestic, foreign), nil
+	}
+
+	maturity, err := time.Parse("2006-01-02", maturityFlag)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maturity %q: %w", maturityFlag, err)
+	}
+
+	switch kind {
+	case "forward":
+		return models.NewForward(maturity, strike, domestic, foreign), nil
+	case "option":
+		optTypeFlag, _ := cmd.Flags().GetString("option-type")
+		optType, err := models.ParseOptionType(strings.ToUpper(optTypeFlag))
+		if err != nil {
+			return nil, err
+		}
+		return models.NewEurOption(optType, strike, maturity, domestic, foreign), nil
+	}
+
+	return nil, fmt.Errorf("unknown contract type %q: expected spot, forward or option", kind)
+}
+
+// newMarketManager creates a market manager with the configured cross routing
+// and arbitrage policy
+func newMarketManager() (*market.Manager, error) {
+	cfg := config.GetConfig().Market
+	marketMgr := market.NewManager(logger)
+
+	vehicle, err := models.ParseCurrency(cfg.VehicleCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vehicle currency: %w", err)
+	}
+	if err := marketMgr.SetVehicleCurrency(vehicle); err != nil {
+		return nil, err
+	}
+
+	routing, err := market.ParseCrossRouting(cfg.CrossRouting)
+	if err != nil {
+		return nil, err
+	}
+	if err := marketMgr.SetCrossRouting(routing); err != nil {
+		return nil, err
+	}
+
+	policy, err := market.ParseArbitragePolicy(cfg.ArbitragePolicy)
+	if err != nil {
+		return nil, err
+	}
+	if err := marketMgr.SetArbitragePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	return marketMgr, nil
+}
+
+// snapshotFromFlags builds a market snapshot from --spot flags, with flat
+// curves and volatilities taken from the market config. Crosses the
+// dependencies need are triangulated from the given pairs.
+func snapshotFromFlags(spots []string, deps market.Dependencies) (market.MarketSnapshot, error) {
+	cfg := config.GetConfig().Market
+	marketMgr, err := newMarketManager()
+	if err != nil {
+		return market.MarketSnapshot{}, err
+	}
+
+	for _, spot := range spots {
+		quote, err := parseSpotFlag(spot)
+		if err != nil {
+			return market.MarketSnapshot{}, err
+		}
+		domestic, foreign, err := parsePair(quote.pair)
+		if err != nil {
+			return market.MarketSnapshot{}, err
+		}
+		pair := models.NewCurrencyPair(foreign, domestic).String()
+
+		if quote.bid > 0 {
+			err = marketMgr.UpdateSpotQuote(pair, market.SpotQuote{Bid: quote.bid, Ask: quote.ask, Source: "cli"})
+		} else {
+			err = marketMgr.UpdateSpotRate(pair, quote.rate)
+		}
+		if err != nil {
+			return market.MarketSnapshot{}, err
+		}
+		for _, currency := range []models.Currency{domestic, foreign} {
+			if err := marketMgr.UpdateDiscountCurve(currency.String(), cfg.DefaultRate, "continuous"); err != nil {
+				return market.MarketSnapshot{}, err
+			}
+		}
+		if err := marketMgr.UpdateVolSurface(pair, cfg.DefaultVolatility); err != nil {
+			return market.MarketSnapshot{}, err
+		}
+	}
+
+	// Derived crosses get the flat volatility of the pairs they come from
+	for _, surface := range deps.VolSurfaces {
+		if _, err := marketMgr.GetVolSurface(surface.Pair); err == nil {
+			continue
+		}
+		if _, err := marketMgr.GetSpotRate(surface.Pair); err != nil {
+			continue
+		}
+		if err := marketMgr.UpdateVolSurface(surface.Pair, cfg.DefaultVolatility); err != nil {
+			return market.MarketSnapshot{}, err
+		}
+	}
+
+	return marketMgr.SnapshotFor(deps), nil
+}
+
+// cashflowRecord is the export form of a cashflow
+type cashflowRecord struct {
+	Date       string  `json:"date"` // empty for immediate payments
+	Currency   string  `json:"currency"`
+	Amount     float64 `json:"amount"`
+	Contingent bool    `json:"contingent"`
+	Source     string  `json:"source,omitempty"`
+}
+
+func toCashflowRecords(flows []models.Cashflow) []cashflowRecord {
+	records := make([]cashflowRecord, len(flows))
+	for i, f := range flows {
+		records[i] = cashflowRecord{
+			Currency:   f.Currency.String(),
+			Amount:     f.Amount,
+			Contingent: f.Contingent,
+			Source:     f.Source,
+		}
+		if !f.Date.IsZero() {
+			records[i].Date = f.Date.Format("2006-01-02")
+		}
+	}
+	return records
+}
+
+// writeCashflowsCSV writes flows as CSV with a header row
+func writeCashflowsCSV(w io.Writer, flows []models.Cashflow) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"date", "currency", "amount", "contingent", "source"}); err != nil {
+		return err
+	}
+	for _, r := range toCashflowRecords(flows) {
+		row := []string{
+			r.Date,
+			r.Currency,
+			strconv.FormatFloat(r.Amount, 'f', -1, 64),
+			strconv.FormatBool(r.Contingent),
+			r.Source,
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// writeCashflowsJSON writes flows as an indented JSON array
+func writeCashflowsJSON(w io.Writer, flows []models.Cashflow) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toCashflowRecords(flows))
 }
 
 func main() {
@@ -11,6 +11,7 @@ import (
 	"github.com/leonc/ficc-pricer/market-gateway/internal/client"
 	"github.com/leonc/ficc-pricer/market-gateway/internal/market"
 	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
 )
 
 // This example demonstrates how to:
@@ -19,8 +20,7 @@ import (
 // 3. Connect to the pricing service
 // 4. Request a price
 //
-// NOTE: This is a placeholder until protobuf schemas are generated.
-// The actual pricing call will work once the proto files are created.
+// NOTE: The pricing call requires the Haskell gRPC service to be running.
 
 func main() {
 	// Initialize logger
@@ -102,47 +102,20 @@ func main() {
 
 	logger.Info("Successfully connected to pricing service")
 
-	// Step 4: Request price (placeholder until protobuf is generated)
-	err = pricerClient.PriceRequest(ctx)
+	// Step 4: Request price
+	params := client.NewPricingParams(time.Now(), pb.Currency_USD, pb.PricingModel_BLACK_SCHOLES)
+
+	resp, err := pricerClient.PriceRequest(ctx, contract, snapshot, params)
 	if err != nil {
 		logger.Error("Price request failed", zap.Error(err))
 		return
 	}
 
-	// Once protobuf is generated, this will look like:
-	/*
-		priceReq := &pb.PriceRequest{
-			Contract: contractToProto(contract),
-			Market:   snapshotToProto(snapshot),
-			Params: &pb.PricingParams{
-				ValuationDate: time.Now().Format(time.RFC3339),
-				Numeraire:     pb.Currency_USD,
-				Model:         pb.PricingModel_BLACK_SCHOLES,
-			},
-		}
-
-		resp, err := pricerClient.Price(ctx, priceReq)
-		if err != nil {
-			logger.Error("Price request failed", zap.Error(err))
-			return
-		}
-
-		logger.Info("Price received",
-			zap.Float64("price", resp.Price),
-			zap.String("numeraire", resp.Numeraire),
-			zap.Float64("computation_time_ms", resp.ComputationTimeMs),
-		)
-	*/
-
 	fmt.Println("\n=== Example Summary ===")
 	fmt.Printf("Contract: %s\n", contract.String())
 	fmt.Printf("Spot: EUR/USD = %.4f\n", snapshot.SpotRates["EUR/USD"].Rate)
 	fmt.Printf("USD Rate: %.2f%%\n", snapshot.DiscountCurves["USD"].FlatRate*100)
 	fmt.Printf("EUR Rate: %.2f%%\n", snapshot.DiscountCurves["EUR"].FlatRate*100)
 	fmt.Printf("Volatility: %.2f%%\n", snapshot.VolSurfaces["EUR/USD"].FlatVol*100)
-	fmt.Println("\nNext steps:")
-	fmt.Println("1. Define protobuf schema (pricer.proto)")
-	fmt.Println("2. Generate Go code: protoc --go_out=. --go-grpc_out=. pricer.proto")
-	fmt.Println("3. Implement Haskell gRPC server")
-	fmt.Println("4. Update this example with real pricing calls")
+	fmt.Printf("Price: %.2f %s (%.2f ms)\n", resp.Price, resp.Numeraire, resp.ComputationTimeMs)
 }
@@ -0,0 +1,481 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
+)
+
+// dateLayout is the ISO 8601 date format used for maturities on the wire
+const dateLayout = "2006-01-02"
+
+// ContractToProto encodes a contract tree into its protobuf representation
+func ContractToProto(c models.Contract) (*pb.Contract, error) {
+	return models.Fold[*pb.Contract](c, protoEncoder{})
+}
+
+// protoEncoder folds a contract into the recursive protobuf Contract.
+// Errors are reported at the node's wire path, e.g.
+// "contract.combine.left.eur_option.option_type".
+type protoEncoder struct{}
+
+// wirePath converts a fold path such as "Combine.Left" into the field path of
+// the protobuf message, "contract.combine.left"
+func wirePath(path string) string {
+	if path == "" {
+		return "contract"
+	}
+	return "contract." + strings.ToLower(path)
+}
+
+func (protoEncoder) VisitNil(path string) (*pb.Contract, error) {
+	return nil, fmt.Errorf("%s: contract is nil", wirePath(path))
+}
+
+func (protoEncoder) VisitZero(string, models.Zero) (*pb.Contract, error) {
+	return &pb.Contract{ContractType: &pb.Contract_Zero{Zero: &pb.Zero{}}}, nil
+}
+
+func (protoEncoder) VisitSpot(path string, c models.Spot) (*pb.Contract, error) {
+	domestic, foreign, err := currencyPairToProto(c.Domestic, c.Foreign, wirePath(path)+".spot")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Contract{ContractType: &pb.Contract_Spot{Spot: &pb.Spot{
+		Domestic: domestic,
+		Foreign:  foreign,
+	}}}, nil
+}
+
+func (protoEncoder) VisitForward(path string, c models.Forward) (*pb.Contract, error) {
+	domestic, foreign, err := currencyPairToProto(c.Domestic, c.Foreign, wirePath(path)+".forward")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Contract{ContractType: &pb.Contract_Forward{Forward: &pb.Forward{
+		Maturity:  c.Maturity.Format(dateLayout),
+		FixedRate: c.FixedRate,
+		Domestic:  domestic,
+		Foreign:   foreign,
+	}}}, nil
+}
+
+func (protoEncoder) VisitEurOption(path string, c models.EurOption) (*pb.Contract, error) {
+	domestic, foreign, err := currencyPairToProto(c.Domestic, c.Foreign, wirePath(path)+".eur_option")
+	if err != nil {
+		return nil, err
+	}
+	optType, err := optionTypeToProto(c.Type, wirePath(path)+".eur_option.option_type")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Contract{ContractType: &pb.Contract_EurOption{EurOption: &pb.EurOption{
+		OptionType: optType,
+		Strike:     c.Strike,
+		Maturity:   c.Maturity.Format(dateLayout),
+		Domestic:   domestic,
+		Foreign:    foreign,
+	}}}, nil
+}
+
+func (protoEncoder) VisitZCB(path string, c models.ZCB) (*pb.Contract, error) {
+	currency, err := currencyToProto(c.Currency, wirePath(path)+".zcb.currency")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Contract{ContractType: &pb.Contract_Zcb{Zcb: &pb.ZCB{
+		Currency: currency,
+		Maturity: c.Maturity.Format(dateLayout),
+	}}}, nil
+}
+
+func (protoEncoder) VisitScale(_ string, c models.Scale, contract *pb.Contract) (*pb.Contract, error) {
+	return &pb.Contract{ContractType: &pb.Contract_Scale{Scale: &pb.Scale{
+		Notional: c.Notional,
+		Contract: contract,
+	}}}, nil
+}
+
+func (protoEncoder) VisitCombine(_ string, _ models.Combine, left, right *pb.Contract) (*pb.Contract, error) {
+	return &pb.Contract{ContractType: &pb.Contract_Combine{Combine: &pb.Combine{
+		Left:  left,
+		Right: right,
+	}}}, nil
+}
+
+func (protoEncoder) VisitWhen(path string, c models.When, contract *pb.Contract) (*pb.Contract, error) {
+	condition, err := observableToProto(c.Condition, wirePath(path)+".when.condition")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Contract{ContractType: &pb.Contract_When{When: &pb.When{
+		Condition: condition,
+		Contract:  contract,
+	}}}, nil
+}
+
+// VisitNamed drops the product name, which is not part of the wire format
+func (protoEncoder) VisitNamed(_ string, _ models.Named, contract *pb.Contract) (*pb.Contract, error) {
+	return contract, nil
+}
+
+// ContractFromProto decodes a protobuf contract tree into the Go models
+func ContractFromProto(msg *pb.Contract) (models.Contract, error) {
+	return contractFromProto(msg, "contract")
+}
+
+func contractFromProto(msg *pb.Contract, path string) (models.Contract, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("%s: contract is unset", path)
+	}
+
+	switch ct := msg.ContractType.(type) {
+	case nil:
+		return nil, fmt.Errorf("%s: contract_type is unset", path)
+
+	case *pb.Contract_Zero:
+		return models.Zero{}, nil
+
+	case *pb.Contract_Spot:
+		if ct.Spot == nil {
+			return nil, fmt.Errorf("%s.spot: message is unset", path)
+		}
+		domestic, foreign, err := currencyPairFromProto(ct.Spot.Domestic, ct.Spot.Foreign, path+".spot")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewSpot(domestic, foreign), nil
+
+	case *pb.Contract_Forward:
+		if ct.Forward == nil {
+			return nil, fmt.Errorf("%s.forward: message is unset", path)
+		}
+		domestic, foreign, err := currencyPairFromProto(ct.Forward.Domestic, ct.Forward.Foreign, path+".forward")
+		if err != nil {
+			return nil, err
+		}
+		maturity, err := dateFromProto(ct.Forward.Maturity, path+".forward.maturity")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewForward(maturity, ct.Forward.FixedRate, domestic, foreign), nil
+
+	case *pb.Contract_EurOption:
+		if ct.EurOption == nil {
+			return nil, fmt.Errorf("%s.eur_option: message is unset", path)
+		}
+		domestic, foreign, err := currencyPairFromProto(ct.EurOption.Domestic, ct.EurOption.Foreign, path+".eur_option")
+		if err != nil {
+			return nil, err
+		}
+		optType, err := optionTypeFromProto(ct.EurOption.OptionType, path+".eur_option.option_type")
+		if err != nil {
+			return nil, err
+		}
+		maturity, err := dateFromProto(ct.EurOption.Maturity, path+".eur_option.maturity")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewEurOption(optType, ct.EurOption.Strike, maturity, domestic, foreign), nil
+
+	case *pb.Contract_Zcb:
+		if ct.Zcb == nil {
+			return nil, fmt.Errorf("%s.zcb: message is unset", path)
+		}
+		currency, err := currencyFromProto(ct.Zcb.Currency, path+".zcb.currency")
+		if err != nil {
+			return nil, err
+		}
+		maturity, err := dateFromProto(ct.Zcb.Maturity, path+".zcb.maturity")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewZCB(currency, maturity), nil
+
+	case *pb.Contract_Scale:
+		if ct.Scale == nil {
+			return nil, fmt.Errorf("%s.scale: message is unset", path)
+		}
+		inner, err := contractFromProto(ct.Scale.Contract, path+".scale.contract")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewScale(ct.Scale.Notional, inner), nil
+
+	case *pb.Contract_Combine:
+		if ct.Combine == nil {
+			return nil, fmt.Errorf("%s.combine: message is unset", path)
+		}
+		left, err := contractFromProto(ct.Combine.Left, path+".combine.left")
+		if err != nil {
+			return nil, err
+		}
+		right, err := contractFromProto(ct.Combine.Right, path+".combine.right")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewCombine(left, right), nil
+
+	case *pb.Contract_When:
+		if ct.When == nil {
+			return nil, fmt.Errorf("%s.when: message is unset", path)
+		}
+		condition, err := observableFromProto(ct.When.Condition, path+".when.condition")
+		if err != nil {
+			return nil, err
+		}
+		cond, ok := condition.(models.BoolObservable)
+		if !ok {
+			return nil, fmt.Errorf("%s.when.condition: expected a boolean observable, got %s", path, condition)
+		}
+		inner, err := contractFromProto(ct.When.Contract, path+".when.contract")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewWhen(cond, inner), nil
+
+	default:
+		return nil, fmt.Errorf("%s: unknown contract_type %T", path, ct)
+	}
+}
+
+func observableToProto(o models.Observable, path string) (*pb.Observable, error) {
+	switch o := o.(type) {
+	case nil:
+		return nil, fmt.Errorf("%s: observable is nil", path)
+
+	case models.ConstBool:
+		return &pb.Observable{ObservableType: &pb.Observable_ConstBool{ConstBool: &pb.ConstBool{
+			Value: o.Value,
+		}}}, nil
+
+	case models.ConstDouble:
+		return &pb.Observable{ObservableType: &pb.Observable_ConstDouble{ConstDouble: &pb.ConstDouble{
+			Value: o.Value,
+		}}}, nil
+
+	case models.SpotRate:
+		domestic, foreign, err := currencyPairToProto(o.Domestic, o.Foreign, path+".spot_rate")
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Observable{ObservableType: &pb.Observable_SpotRate{SpotRate: &pb.SpotRate{
+			Domestic: domestic,
+			Foreign:  foreign,
+		}}}, nil
+
+	case models.FwdRate:
+		domestic, foreign, err := currencyPairToProto(o.Domestic, o.Foreign, path+".fwd_rate")
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Observable{ObservableType: &pb.Observable_FwdRate{FwdRate: &pb.FwdRate{
+			Domestic: domestic,
+			Foreign:  foreign,
+			Maturity: o.Maturity.Format(dateLayout),
+		}}}, nil
+
+	case models.Barrier:
+		direction, err := directionToProto(o.Direction, path+".barrier.direction")
+		if err != nil {
+			return nil, err
+		}
+		underlying, err := observableToProto(o.Underlying, path+".barrier.underlying")
+		if err != nil {
+			return nil, err
+		}
+		return &pb.Observable{ObservableType: &pb.Observable_Barrier{Barrier: &pb.Barrier{
+			Direction:  direction,
+			Level:      o.Level,
+			Underlying: underlying,
+		}}}, nil
+
+	default:
+		return nil, fmt.Errorf("%s: unsupported observable type %T", path, o)
+	}
+}
+
+func observableFromProto(msg *pb.Observable, path string) (models.Observable, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("%s: observable is unset", path)
+	}
+
+	switch ot := msg.ObservableType.(type) {
+	case nil:
+		return nil, fmt.Errorf("%s: observable_type is unset", path)
+
+	case *pb.Observable_ConstBool:
+		if ot.ConstBool == nil {
+			return nil, fmt.Errorf("%s.const_bool: message is unset", path)
+		}
+		return models.NewConstBool(ot.ConstBool.Value), nil
+
+	case *pb.Observable_ConstDouble:
+		if ot.ConstDouble == nil {
+			return nil, fmt.Errorf("%s.const_double: message is unset", path)
+		}
+		return models.NewConstDouble(ot.ConstDouble.Value), nil
+
+	case *pb.Observable_SpotRate:
+		if ot.SpotRate == nil {
+			return nil, fmt.Errorf("%s.spot_rate: message is unset", path)
+		}
+		domestic, foreign, err := currencyPairFromProto(ot.SpotRate.Domestic, ot.SpotRate.Foreign, path+".spot_rate")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewSpotRate(domestic, foreign), nil
+
+	case *pb.Observable_FwdRate:
+		if ot.FwdRate == nil {
+			return nil, fmt.Errorf("%s.fwd_rate: message is unset", path)
+		}
+		domestic, foreign, err := currencyPairFromProto(ot.FwdRate.Domestic, ot.FwdRate.Foreign, path+".fwd_rate")
+		if err != nil {
+			return nil, err
+		}
+		maturity, err := dateFromProto(ot.FwdRate.Maturity, path+".fwd_rate.maturity")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewFwdRate(domestic, foreign, maturity), nil
+
+	case *pb.Observable_Barrier:
+		if ot.Barrier == nil {
+			return nil, fmt.Errorf("%s.barrier: message is unset", path)
+		}
+		direction, err := directionFromProto(ot.Barrier.Direction, path+".barrier.direction")
+		if err != nil {
+			return nil, err
+		}
+		underlying, err := observableFromProto(ot.Barrier.Underlying, path+".barrier.underlying")
+		if err != nil {
+			return nil, err
+		}
+		value, ok := underlying.(models.DoubleObservable)
+		if !ok {
+			return nil, fmt.Errorf("%s.barrier.underlying: expected a numeric observable, got %s", path, underlying)
+		}
+		return models.NewBarrier(direction, ot.Barrier.Level, value), nil
+
+	default:
+		return nil, fmt.Errorf("%s: unknown observable_type %T", path, ot)
+	}
+}
+
+// CurrencyToProto maps a registered currency onto the protobuf enum by ISO
+// code. Currencies the pricing service does not know are rejected.
+func CurrencyToProto(c models.Currency) (pb.Currency, error) {
+	code := c.String()
+	if code == "" {
+		return 0, fmt.Errorf("invalid currency %d", int(c))
+	}
+	value, ok := pb.Currency_value[code]
+	if !ok {
+		return 0, fmt.Errorf("currency %s is not supported by the pricing service", code)
+	}
+	return pb.Currency(value), nil
+}
+
+func currencyToProto(c models.Currency, path string) (pb.Currency, error) {
+	value, err := CurrencyToProto(c)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	return value, nil
+}
+
+// currencyFromProto maps a protobuf currency onto the model enum by ISO code
+func currencyFromProto(c pb.Currency, path string) (models.Currency, error) {
+	name, ok := pb.Currency_name[int32(c)]
+	if !ok {
+		return 0, fmt.Errorf("%s: invalid currency %d", path, int32(c))
+	}
+	currency, err := models.ParseCurrency(name)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", path, err)
+	}
+	return currency, nil
+}
+
+func currencyPairToProto(domestic, foreign models.Currency, path string) (pb.Currency, pb.Currency, error) {
+	d, err := currencyToProto(domestic, path+".domestic")
+	if err != nil {
+		return 0, 0, err
+	}
+	f, err := currencyToProto(foreign, path+".foreign")
+	if err != nil {
+		return 0, 0, err
+	}
+	return d, f, nil
+}
+
+func currencyPairFromProto(domestic, foreign pb.Currency, path string) (models.Currency, models.Currency, error) {
+	d, err := currencyFromProto(domestic, path+".domestic")
+	if err != nil {
+		return 0, 0, err
+	}
+	f, err := currencyFromProto(foreign, path+".foreign")
+	if err != nil {
+		return 0, 0, err
+	}
+	return d, f, nil
+}
+
+func optionTypeToProto(o models.OptionType, path string) (pb.OptionType, error) {
+	switch o {
+	case models.Call:
+		return pb.OptionType_CALL, nil
+	case models.Put:
+		return pb.OptionType_PUT, nil
+	default:
+		return 0, fmt.Errorf("%s: invalid option type %d", path, int(o))
+	}
+}
+
+func optionTypeFromProto(o pb.OptionType, path string) (models.OptionType, error) {
+	switch o {
+	case pb.OptionType_CALL:
+		return models.Call, nil
+	case pb.OptionType_PUT:
+		return models.Put, nil
+	default:
+		return 0, fmt.Errorf("%s: invalid option type %d", path, int32(o))
+	}
+}
+
+func directionToProto(d models.Direction, path string) (pb.Direction, error) {
+	switch d {
+	case models.Up:
+		return pb.Direction_UP, nil
+	case models.Down:
+		return pb.Direction_DOWN, nil
+	default:
+		return 0, fmt.Errorf("%s: invalid direction %d", path, int(d))
+	}
+}
+
+func directionFromProto(d pb.Direction, path string) (models.Direction, error) {
+	switch d {
+	case pb.Direction_UP:
+		return models.Up, nil
+	case pb.Direction_DOWN:
+		return models.Down, nil
+	default:
+		return 0, fmt.Errorf("%s: invalid direction %d", path, int32(d))
+	}
+}
+
+func dateFromProto(s, path string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, fmt.Errorf("%s: date is unset", path)
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: invalid date %q: %w", path, s, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
+)
+
+func TestContractProtoRoundTrip(t *testing.T) {
+	maturity := time.Date(2030, 6, 28, 0, 0, 0, 0, time.UTC)
+	call := models.NewCallOption(1.15, maturity, models.USD, models.EUR)
+
+	knockIn, err := models.NewUpAndInOption(1.25, models.Call, 1.15, maturity, models.USD, models.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	knockOut, err := models.NewDownAndOutOption(1.05, models.Put, 1.10, maturity, models.USD, models.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]models.Contract{
+		"zero":      models.Zero{},
+		"spot":      models.NewSpot(models.JPY, models.USD),
+		"forward":   models.NewForward(maturity, 1.1234567, models.USD, models.GBP),
+		"call":      call,
+		"put":       models.NewPutOption(0.95, maturity, models.CHF, models.AUD),
+		"knock-in":  knockIn,
+		"knock-out": knockOut,
+		"zcb":       models.NewZCB(models.CAD, maturity),
+		"scale":     models.NewScale(-2.5e6, call),
+		"combine":   models.NewCombine(models.NewCombine(call, models.Zero{}), models.NewZCB(models.USD, maturity)),
+		"when fwd": models.NewWhen(
+			models.NewBarrier(models.Down, 1.3, models.NewFwdRate(models.USD, models.GBP, maturity)),
+			models.NewScale(3, models.NewSpot(models.USD, models.GBP)),
+		),
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			msg, err := ContractToProto(c)
+			if err != nil {
+				t.Fatalf("ContractToProto: %v", err)
+			}
+			// Go through the wire encoding too
+			data, err := proto.Marshal(msg)
+			if err != nil {
+				t.Fatalf("proto.Marshal: %v", err)
+			}
+			var decoded pb.Contract
+			if err := proto.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("proto.Unmarshal: %v", err)
+			}
+
+			got, err := ContractFromProto(&decoded)
+			if err != nil {
+				t.Fatalf("ContractFromProto: %v", err)
+			}
+			if !models.Equal(got, c) {
+				t.Fatalf("round trip = %s, want %s", got, c)
+			}
+		})
+	}
+}
+
+func TestNamedIsTransparentOnTheWire(t *testing.T) {
+	maturity := time.Date(2030, 6, 28, 0, 0, 0, 0, time.UTC)
+	call := models.NewCallOption(1.15, maturity, models.USD, models.EUR)
+
+	named, err := ContractToProto(models.NewNamed("call", call))
+	if err != nil {
+		t.Fatalf("ContractToProto: %v", err)
+	}
+	plain, err := ContractToProto(call)
+	if err != nil {
+		t.Fatalf("ContractToProto: %v", err)
+	}
+	if !proto.Equal(named, plain) {
+		t.Fatalf("named contract encodes as %v, want %v", named, plain)
+	}
+
+	got, err := ContractFromProto(named)
+	if err != nil {
+		t.Fatalf("ContractFromProto: %v", err)
+	}
+	if !models.Equal(got, call) {
+		t.Fatalf("round trip = %s, want %s", got, call)
+	}
+}
+
+func TestContractToProtoErrors(t *testing.T) {
+	maturity := time.Date(2030, 6, 28, 0, 0, 0, 0, time.UTC)
+	nok, err := models.ParseCurrency("NOK")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		contract models.Contract
+		want     string
+	}{
+		{"nil root", nil, "contract: contract is nil"},
+		{"nil child", models.NewCombine(models.Zero{}, nil), "contract.combine.right: contract is nil"},
+		{"unsupported currency", models.NewScale(2, models.NewCallOption(10, maturity, nok, models.USD)),
+			"contract.scale.contract.eur_option.domestic: currency NOK is not supported"},
+		{"nil observable", models.NewWhen(nil, models.Zero{}), "contract.when.condition: observable is nil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ContractToProto(tt.contract)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestContractFromProtoErrors(t *testing.T) {
+	spot := &pb.Contract{ContractType: &pb.Contract_Spot{Spot: &pb.Spot{Domestic: pb.Currency_USD, Foreign: pb.Currency_EUR}}}
+
+	tests := []struct {
+		name string
+		msg  *pb.Contract
+		want string
+	}{
+		{"nil message", nil, "contract: contract is unset"},
+		{"unset oneof", &pb.Contract{}, "contract: contract_type is unset"},
+		{"unset oneof message", &pb.Contract{ContractType: &pb.Contract_Scale{}}, "contract.scale: message is unset"},
+		{"nil child", &pb.Contract{ContractType: &pb.Contract_Combine{Combine: &pb.Combine{Left: spot}}},
+			"contract.combine.right: contract is unset"},
+		{"unknown currency", &pb.Contract{ContractType: &pb.Contract_Zcb{Zcb: &pb.ZCB{Currency: pb.Currency(99), Maturity: "2030-06-28"}}},
+			"contract.zcb.currency: invalid currency 99"},
+		{"invalid date", &pb.Contract{ContractType: &pb.Contract_Zcb{Zcb: &pb.ZCB{Currency: pb.Currency_USD, Maturity: "28/06/2030"}}},
+			"contract.zcb.maturity: invalid date"},
+		{"unknown observable", &pb.Contract{ContractType: &pb.Contract_When{When: &pb.When{Condition: &pb.Observable{}, Contract: spot}}},
+			"contract.when.condition: observable_type is unset"},
+		{"numeric condition", &pb.Contract{ContractType: &pb.Contract_When{When: &pb.When{
+			Condition: &pb.Observable{ObservableType: &pb.Observable_ConstDouble{ConstDouble: &pb.ConstDouble{Value: 1}}},
+			Contract:  spot,
+		}}}, "contract.when.condition: expected a boolean observable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ContractFromProto(tt.msg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyToProto(t *testing.T) {
+	for _, c := range []models.Currency{models.USD, models.EUR, models.GBP, models.JPY, models.CHF, models.AUD, models.CAD} {
+		value, err := CurrencyToProto(c)
+		if err != nil {
+			t.Fatalf("CurrencyToProto(%s): %v", c, err)
+		}
+		if value.String() != c.String() {
+			t.Errorf("CurrencyToProto(%s) = %s", c, value)
+		}
+	}
+
+	// Registered currencies the pricing service does not know are rejected by code
+	nok, err := models.ParseCurrency("NOK")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = CurrencyToProto(nok)
+	if want := "currency NOK is not supported by the pricing service"; err == nil || err.Error() != want {
+		t.Errorf("CurrencyToProto(NOK) error = %v, want %q", err, want)
+	}
+	if _, err := CurrencyToProto(models.Currency(-1)); err == nil || err.Error() != "invalid currency -1" {
+		t.Errorf("CurrencyToProto(-1) error = %v, want invalid currency -1", err)
+	}
+}
@@ -0,0 +1,481 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/market"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
+)
+
+// SnapshotToProto encodes a market snapshot into its protobuf representation
+func SnapshotToProto(snapshot market.MarketSnapshot) (*pb.MarketSnapshot, error) {
+	msg := &pb.MarketSnapshot{
+		SpotRates:      make(map[string]float64, len(snapshot.SpotRates)),
+		DiscountCurves: make(map[string]*pb.DiscountCurve, len(snapshot.DiscountCurves)),
+		VolSurfaces:    make(map[string]*pb.VolSurface, len(snapshot.VolSurfaces)),
+		Correlations:   make(map[string]float64, len(snapshot.Correlations)),
+		SpotQuotes:     make(map[string]*pb.SpotQuote, len(snapshot.SpotRates)),
+	}
+
+	for pair, spot := range snapshot.SpotRates {
+		quote, err := SpotQuoteToProto(spot)
+		if err != nil {
+			return nil, fmt.Errorf("spot_rates[%s]: %w", pair, err)
+		}
+		msg.SpotRates[pair] = spot.Rate
+		msg.SpotQuotes[pair] = quote
+	}
+
+	for currency, curve := range snapshot.DiscountCurves {
+		curveMsg, err := DiscountCurveToProto(curve)
+		if err != nil {
+			return nil, fmt.Errorf("discount_curves[%s]: %w", currency, err)
+		}
+		msg.DiscountCurves[currency] = curveMsg
+	}
+
+	for pair, surface := range snapshot.VolSurfaces {
+		surfaceMsg, err := VolSurfaceToProto(surface)
+		if err != nil {
+			return nil, fmt.Errorf("vol_surfaces[%s]: %w", pair, err)
+		}
+		msg.VolSurfaces[pair] = surfaceMsg
+	}
+
+	for key, corr := range snapshot.Correlations {
+		msg.Correlations[key] = corr
+	}
+
+	return msg, nil
+}
+
+// SnapshotFromProto decodes a protobuf market snapshot. Spot quotes keep
+// their own timestamps; every other entry is stamped with snapshotTime.
+func SnapshotFromProto(msg *pb.MarketSnapshot, snapshotTime time.Time) (market.MarketSnapshot, error) {
+	if msg == nil {
+		return market.MarketSnapshot{}, fmt.Errorf("market snapshot is unset")
+	}
+
+	snapshot := market.MarketSnapshot{
+		SpotRates:      make(map[string]market.SpotRate, len(msg.SpotRates)),
+		DiscountCurves: make(map[string]market.DiscountCurve, len(msg.DiscountCurves)),
+		VolSurfaces:    make(map[string]market.VolSurface, len(msg.VolSurfaces)),
+		Correlations:   make(map[string]float64, len(msg.Correlations)),
+		SnapshotTime:   snapshotTime,
+	}
+
+	for pair, rate := range msg.SpotRates {
+		snapshot.SpotRates[pair] = market.SpotRate{
+			Pair:      pair,
+			Rate:      rate,
+			Timestamp: snapshotTime,
+		}
+	}
+
+	// Full quotes take precedence over the mid-only spot_rates map
+	for pair, quoteMsg := range msg.SpotQuotes {
+		spot, err := SpotQuoteFromProto(pair, quoteMsg, snapshotTime)
+		if err != nil {
+			return market.MarketSnapshot{}, fmt.Errorf("spot_quotes[%s]: %w", pair, err)
+		}
+		snapshot.SpotRates[pair] = spot
+	}
+
+	for currency, curveMsg := range msg.DiscountCurves {
+		curve, err := DiscountCurveFromProto(currency, curveMsg)
+		if err != nil {
+			return market.MarketSnapshot{}, fmt.Errorf("discount_curves[%s]: %w", currency, err)
+		}
+		curve.Timestamp = snapshotTime
+		snapshot.DiscountCurves[currency] = curve
+	}
+
+	for pair, surfaceMsg := range msg.VolSurfaces {
+		surface, err := VolSurfaceFromProto(pair, surfaceMsg)
+		if err != nil {
+			return market.MarketSnapshot{}, fmt.Errorf("vol_surfaces[%s]: %w", pair, err)
+		}
+		surface.Timestamp = snapshotTime
+		snapshot.VolSurfaces[pair] = surface
+	}
+
+	for key, corr := range msg.Correlations {
+		snapshot.Correlations[key] = corr
+	}
+
+	return snapshot, nil
+}
+
+// DiscountCurveToProto encodes a discount curve. Curves with pillars are sent
+// as a PillarCurve, otherwise as a FlatRate.
+func DiscountCurveToProto(curve market.DiscountCurve) (*pb.DiscountCurve, error) {
+	if len(curve.Pillars) == 0 {
+		return &pb.DiscountCurve{CurveType: &pb.DiscountCurve_FlatRate{FlatRate: &pb.FlatRate{
+			Rate:        curve.FlatRate,
+			Compounding: curve.Compounding.String(),
+			DayCount:    curve.DayCount.String(),
+		}}}, nil
+	}
+
+	if err := curve.Validate(); err != nil {
+		return nil, fmt.Errorf("pillar_curve: %w", err)
+	}
+	pillars, err := curve.ResolvedPillars()
+	if err != nil {
+		return nil, fmt.Errorf("pillar_curve: %w", err)
+	}
+
+	points := make([]*pb.DateValue, 0, len(pillars))
+	for _, pillar := range pillars {
+		points = append(points, &pb.DateValue{
+			Date:  pillar.Date.Format(dateLayout),
+			Value: pillar.Value,
+		})
+	}
+
+	pillarType := curve.PillarType
+	if pillarType == "" {
+		pillarType = market.PillarZeroRate
+	}
+
+	return &pb.DiscountCurve{CurveType: &pb.DiscountCurve_PillarCurve{PillarCurve: &pb.PillarCurve{
+		Points:        points,
+		Interpolation: curve.Interpolation,
+		ValueType:     pillarType,
+		ReferenceDate: curve.ReferenceDate.Format(dateLayout),
+		Compounding:   curve.Compounding.String(),
+		DayCount:      curve.DayCount.String(),
+	}}}, nil
+}
+
+// DiscountCurveFromProto decodes a discount curve for the given currency
+func DiscountCurveFromProto(currency string, msg *pb.DiscountCurve) (market.DiscountCurve, error) {
+	if msg == nil {
+		return market.DiscountCurve{}, fmt.Errorf("curve is unset")
+	}
+
+	switch ct := msg.CurveType.(type) {
+	case nil:
+		return market.DiscountCurve{}, fmt.Errorf("curve_type is unset")
+
+	case *pb.DiscountCurve_FlatRate:
+		if ct.FlatRate == nil {
+			return market.DiscountCurve{}, fmt.Errorf("flat_rate: message is unset")
+		}
+		convention, err := rateConventionFromProto(ct.FlatRate.GetCompounding(), ct.FlatRate.GetDayCount())
+		if err != nil {
+			return market.DiscountCurve{}, fmt.Errorf("flat_rate: %w", err)
+		}
+		return market.DiscountCurve{
+			Currency:    currency,
+			FlatRate:    ct.FlatRate.Rate,
+			Compounding: convention.Compounding,
+			DayCount:    convention.DayCount,
+		}, nil
+
+	case *pb.DiscountCurve_PillarCurve:
+		if ct.PillarCurve == nil {
+			return market.DiscountCurve{}, fmt.Errorf("pillar_curve: message is unset")
+		}
+		pillars := make([]market.CurvePillar, 0, len(ct.PillarCurve.Points))
+		for i, point := range ct.PillarCurve.Points {
+			date, err := dateFromProto(point.GetDate(), fmt.Sprintf("pillar_curve.points[%d].date", i))
+			if err != nil {
+				return market.DiscountCurve{}, err
+			}
+			pillars = append(pillars, market.CurvePillar{
+				Date:  date,
+				Value: point.GetValue(),
+			})
+		}
+		referenceDate, err := dateFromProto(ct.PillarCurve.GetReferenceDate(), "pillar_curve.reference_date")
+		if err != nil {
+			return market.DiscountCurve{}, err
+		}
+		convention, err := rateConventionFromProto(ct.PillarCurve.GetCompounding(), ct.PillarCurve.GetDayCount())
+		if err != nil {
+			return market.DiscountCurve{}, fmt.Errorf("pillar_curve: %w", err)
+		}
+		curve := market.DiscountCurve{
+			Currency:      currency,
+			Compounding:   convention.Compounding,
+			DayCount:      convention.DayCount,
+			ReferenceDate: referenceDate,
+			Pillars:       pillars,
+			PillarType:    ct.PillarCurve.ValueType,
+			Interpolation: ct.PillarCurve.Interpolation,
+		}
+		if err := curve.Validate(); err != nil {
+			return market.DiscountCurve{}, fmt.Errorf("pillar_curve: %w", err)
+		}
+		return curve, nil
+
+	default:
+		return market.DiscountCurve{}, fmt.Errorf("unknown curve_type %T", ct)
+	}
+}
+
+// rateConventionFromProto parses a curve's compounding and day count. Unset
+// fields mean continuous and ACT/365F, which senders that predate them assumed.
+func rateConventionFromProto(compounding, dayCount string) (market.RateConvention, error) {
+	var convention market.RateConvention
+	var err error
+	if compounding != "" {
+		if convention.Compounding, err = market.ParseCompounding(compounding); err != nil {
+			return market.RateConvention{}, err
+		}
+	}
+	if dayCount != "" {
+		if convention.DayCount, err = market.ParseDayCount(dayCount); err != nil {
+			return market.RateConvention{}, err
+		}
+	}
+	return convention, nil
+}
+
+// VolSurfaceToProto encodes a volatility surface. Surfaces with grid points
+// are sent as a VolGrid, otherwise as a FlatVol.
+func VolSurfaceToProto(surface market.VolSurface) (*pb.VolSurface, error) {
+	if !surface.IsGrid() {
+		return &pb.VolSurface{SurfaceType: &pb.VolSurface_FlatVol{FlatVol: &pb.FlatVol{
+			Volatility: surface.FlatVol,
+		}}}, nil
+	}
+
+	for i, point := range surface.Points {
+		if point.Maturity.IsZero() {
+			return nil, fmt.Errorf("vol_grid.points[%d]: maturity is unset", i)
+		}
+	}
+	if err := surface.Validate(); err != nil {
+		return nil, fmt.Errorf("vol_grid: %w", err)
+	}
+
+	points := make([]*pb.VolPoint, 0, len(surface.Points))
+	for _, slice := range surface.Slices() {
+		for _, point := range slice {
+			points = append(points, &pb.VolPoint{
+				Strike:     point.Strike,
+				Maturity:   point.Maturity.Format(dateLayout),
+				Volatility: point.Volatility,
+			})
+		}
+	}
+
+	fits := make([]*pb.SmileFit, 0, len(surface.Fits))
+	for i, fit := range surface.Fits {
+		fitMsg, err := smileFitToProto(fit)
+		if err != nil {
+			return nil, fmt.Errorf("vol_grid.fits[%d]: %w", i, err)
+		}
+		fits = append(fits, fitMsg)
+	}
+
+	return &pb.VolSurface{SurfaceType: &pb.VolSurface_VolGrid{VolGrid: &pb.VolGrid{
+		Points:        points,
+		Interpolation: surface.Interpolation,
+		ReferenceDate: surface.ReferenceDate.Format(dateLayout),
+		Fits:          fits,
+	}}}, nil
+}
+
+// VolSurfaceFromProto decodes a volatility surface for the given pair
+func VolSurfaceFromProto(pair string, msg *pb.VolSurface) (market.VolSurface, error) {
+	if msg == nil {
+		return market.VolSurface{}, fmt.Errorf("surface is unset")
+	}
+
+	switch st := msg.SurfaceType.(type) {
+	case nil:
+		return market.VolSurface{}, fmt.Errorf("surface_type is unset")
+
+	case *pb.VolSurface_FlatVol:
+		if st.FlatVol == nil {
+			return market.VolSurface{}, fmt.Errorf("flat_vol: message is unset")
+		}
+		return market.VolSurface{
+			Pair:    pair,
+			FlatVol: st.FlatVol.Volatility,
+		}, nil
+
+	case *pb.VolSurface_VolGrid:
+		if st.VolGrid == nil {
+			return market.VolSurface{}, fmt.Errorf("vol_grid: message is unset")
+		}
+		points := make([]market.VolPoint, 0, len(st.VolGrid.Points))
+		for i, point := range st.VolGrid.Points {
+			maturity, err := dateFromProto(point.GetMaturity(), fmt.Sprintf("vol_grid.points[%d].maturity", i))
+			if err != nil {
+				return market.VolSurface{}, err
+			}
+			points = append(points, market.VolPoint{
+				Strike:     point.GetStrike(),
+				Maturity:   maturity,
+				Volatility: point.GetVolatility(),
+			})
+		}
+		referenceDate, err := dateFromProto(st.VolGrid.GetReferenceDate(), "vol_grid.reference_date")
+		if err != nil {
+			return market.VolSurface{}, err
+		}
+		fits := make([]market.SmileFit, 0, len(st.VolGrid.Fits))
+		for i, fitMsg := range st.VolGrid.Fits {
+			fit, err := smileFitFromProto(fitMsg)
+			if err != nil {
+				return market.VolSurface{}, fmt.Errorf("vol_grid.fits[%d]: %w", i, err)
+			}
+			fits = append(fits, fit)
+		}
+		surface := market.VolSurface{
+			Pair:          pair,
+			ReferenceDate: referenceDate,
+			Points:        points,
+			Interpolation: st.VolGrid.Interpolation,
+		}
+		if len(fits) > 0 {
+			surface.Fits = fits
+		}
+		if err := surface.Validate(); err != nil {
+			return market.VolSurface{}, fmt.Errorf("vol_grid: %w", err)
+		}
+		return surface, nil
+
+	default:
+		return market.VolSurface{}, fmt.Errorf("unknown surface_type %T", st)
+	}
+}
+
+// smileFitToProto encodes a calibrated smile. Fits read through an inverted
+// pair are rejected; encode the surface in the orientation it was stored.
+func smileFitToProto(fit market.SmileFit) (*pb.SmileFit, error) {
+	if fit.Inverted {
+		return nil, fmt.Errorf("fit for %s is inverted", fit.Maturity.Format(dateLayout))
+	}
+	msg := &pb.SmileFit{
+		Maturity:  fit.Maturity.Format(dateLayout),
+		Forward:   fit.Forward,
+		Residuals: fit.Residuals,
+		Rmse:      fit.RMSE,
+	}
+	switch {
+	case fit.SABR != nil:
+		msg.Model = &pb.SmileFit_Sabr{Sabr: &pb.SABRParams{
+			Alpha: fit.SABR.Alpha,
+			Beta:  fit.SABR.Beta,
+			Rho:   fit.SABR.Rho,
+			Nu:    fit.SABR.Nu,
+		}}
+	case fit.SVI != nil:
+		msg.Model = &pb.SmileFit_Svi{Svi: &pb.SVIParams{
+			A:     fit.SVI.A,
+			B:     fit.SVI.B,
+			Rho:   fit.SVI.Rho,
+			M:     fit.SVI.M,
+			Sigma: fit.SVI.Sigma,
+		}}
+	default:
+		return nil, fmt.Errorf("fit for %s has no model", fit.Maturity.Format(dateLayout))
+	}
+	return msg, nil
+}
+
+// smileFitFromProto decodes a calibrated smile
+func smileFitFromProto(msg *pb.SmileFit) (market.SmileFit, error) {
+	if msg == nil {
+		return market.SmileFit{}, fmt.Errorf("message is unset")
+	}
+	maturity, err := dateFromProto(msg.GetMaturity(), "maturity")
+	if err != nil {
+		return market.SmileFit{}, err
+	}
+	fit := market.SmileFit{
+		Maturity:  maturity,
+		Forward:   msg.GetForward(),
+		Residuals: msg.GetResiduals(),
+		RMSE:      msg.GetRmse(),
+	}
+
+	switch model := msg.Model.(type) {
+	case nil:
+		return market.SmileFit{}, fmt.Errorf("model is unset")
+	case *pb.SmileFit_Sabr:
+		if model.Sabr == nil {
+			return market.SmileFit{}, fmt.Errorf("sabr: message is unset")
+		}
+		fit.SABR = &market.SABRParams{
+			Alpha: model.Sabr.GetAlpha(),
+			Beta:  model.Sabr.GetBeta(),
+			Rho:   model.Sabr.GetRho(),
+			Nu:    model.Sabr.GetNu(),
+		}
+	case *pb.SmileFit_Svi:
+		if model.Svi == nil {
+			return market.SmileFit{}, fmt.Errorf("svi: message is unset")
+		}
+		fit.SVI = &market.SVIParams{
+			A:     model.Svi.GetA(),
+			B:     model.Svi.GetB(),
+			Rho:   model.Svi.GetRho(),
+			M:     model.Svi.GetM(),
+			Sigma: model.Svi.GetSigma(),
+		}
+	default:
+		return market.SmileFit{}, fmt.Errorf("unknown model %T", model)
+	}
+	return fit, nil
+}
+
+// SpotQuoteToProto encodes a spot rate with its two-way quote, source and sequence
+func SpotQuoteToProto(spot market.SpotRate) (*pb.SpotQuote, error) {
+	if spot.IsTwoWay() {
+		if err := market.ValidateQuote(spot.Bid, spot.Ask); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := &pb.SpotQuote{
+		Mid:      spot.Rate,
+		Source:   spot.Source,
+		Sequence: spot.Sequence,
+	}
+	if spot.IsTwoWay() {
+		msg.Bid, msg.Ask = spot.Bid, spot.Ask
+	}
+	if !spot.Timestamp.IsZero() {
+		msg.TimestampMs = spot.Timestamp.UnixMilli()
+	}
+	return msg, nil
+}
+
+// SpotQuoteFromProto decodes a spot quote. A missing mid is derived from bid
+// and ask; a missing timestamp defaults to defaultTime.
+func SpotQuoteFromProto(pair string, msg *pb.SpotQuote, defaultTime time.Time) (market.SpotRate, error) {
+	if msg == nil {
+		return market.SpotRate{}, fmt.Errorf("message is unset")
+	}
+
+	spot := market.SpotRate{
+		Pair:      pair,
+		Rate:      msg.Mid,
+		Source:    msg.Source,
+		Sequence:  msg.Sequence,
+		Timestamp: defaultTime,
+	}
+	if msg.Bid != 0 || msg.Ask != 0 {
+		if err := market.ValidateQuote(msg.Bid, msg.Ask); err != nil {
+			return market.SpotRate{}, err
+		}
+		spot.Bid, spot.Ask = msg.Bid, msg.Ask
+		if spot.Rate == 0 {
+			spot.Rate = (msg.Bid + msg.Ask) / 2
+		}
+	}
+	if spot.Rate <= 0 {
+		return market.SpotRate{}, fmt.Errorf("invalid mid %f: must be positive", spot.Rate)
+	}
+	if msg.TimestampMs != 0 {
+		spot.Timestamp = time.UnixMilli(msg.TimestampMs)
+	}
+	return spot, nil
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/market"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
+)
+
+func TestSnapshotProtoRoundTrip(t *testing.T) {
+	snapshotTime := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	reference := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	expiry3m := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	expiry1y := time.Date(2027, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	// Points are listed in the order the encoder emits them, by maturity then strike
+	grid := []market.VolPoint{
+		{Strike: 1.05, Maturity: expiry3m, Volatility: 0.085},
+		{Strike: 1.10, Maturity: expiry3m, Volatility: 0.080},
+		{Strike: 1.15, Maturity: expiry3m, Volatility: 0.083},
+		{Strike: 1.05, Maturity: expiry1y, Volatility: 0.092},
+		{Strike: 1.10, Maturity: expiry1y, Volatility: 0.088},
+		{Strike: 1.15, Maturity: expiry1y, Volatility: 0.090},
+	}
+
+	snapshot := market.MarketSnapshot{
+		SpotRates: map[string]market.SpotRate{
+			"EUR/USD": {
+				Pair: "EUR/USD", Rate: 1.1, Bid: 1.0999, Ask: 1.1001,
+				Source: "EBS", Sequence: 42, Timestamp: time.Date(2026, 3, 2, 9, 29, 59, 250e6, time.UTC),
+			},
+			"USD/JPY": {
+				Pair: "USD/JPY", Rate: 150.02, Bid: 150.01, Ask: 150.03,
+				Source: "Reuters", Sequence: 7, Timestamp: time.Date(2026, 3, 2, 9, 29, 58, 0, time.UTC),
+			},
+			"GBP/USD": {Pair: "GBP/USD", Rate: 1.27, Timestamp: snapshotTime},
+		},
+		DiscountCurves: map[string]market.DiscountCurve{
+			"USD": {
+				Currency: "USD", FlatRate: 0.045, Compounding: market.CompoundAnnual,
+				DayCount: market.DayCountAct360, Timestamp: snapshotTime,
+			},
+			"EUR": {
+				Currency:      "EUR",
+				Compounding:   market.CompoundContinuous,
+				DayCount:      market.DayCountAct365F,
+				ReferenceDate: reference,
+				Pillars: []market.CurvePillar{
+					{Date: expiry3m, Value: 0.025},
+					{Date: expiry1y, Value: 0.027},
+				},
+				PillarType:    market.PillarZeroRate,
+				Interpolation: market.InterpLinearZero,
+				Timestamp:     snapshotTime,
+			},
+		},
+		VolSurfaces: map[string]market.VolSurface{
+			"GBP/USD": {Pair: "GBP/USD", FlatVol: 0.07, Timestamp: snapshotTime},
+			"EUR/USD": {
+				Pair:          "EUR/USD",
+				ReferenceDate: reference,
+				Points:        grid,
+				Interpolation: market.VolInterpSABR,
+				Fits: []market.SmileFit{
+					{
+						Maturity: expiry3m, Forward: 1.103,
+						SABR:      &market.SABRParams{Alpha: 0.08, Beta: 0.5, Rho: -0.2, Nu: 0.6},
+						Residuals: []float64{0.001, -0.0005, 0.0002}, RMSE: 0.00065,
+					},
+					{
+						Maturity: expiry1y, Forward: 1.112,
+						SABR:      &market.SABRParams{Alpha: 0.09, Beta: 0.5, Rho: -0.15, Nu: 0.45},
+						Residuals: []float64{0.0003, 0, -0.0004}, RMSE: 0.0003,
+					},
+				},
+				Timestamp: snapshotTime,
+			},
+			"USD/JPY": {
+				Pair:          "USD/JPY",
+				ReferenceDate: reference,
+				Points: []market.VolPoint{
+					{Strike: 145, Maturity: expiry3m, Volatility: 0.11},
+					{Strike: 150, Maturity: expiry3m, Volatility: 0.10},
+					{Strike: 155, Maturity: expiry3m, Volatility: 0.105},
+				},
+				Interpolation: market.VolInterpSVI,
+				Fits: []market.SmileFit{{
+					Maturity: expiry3m, Forward: 149.2,
+					SVI:       &market.SVIParams{A: 0.001, B: 0.02, Rho: -0.3, M: 0.01, Sigma: 0.1},
+					Residuals: []float64{-0.0002, 0.0001, 0.0001}, RMSE: 0.00014,
+				}},
+				Timestamp: snapshotTime,
+			},
+		},
+		Correlations: map[string]float64{
+			"EUR/USD/USD/JPY": -0.35,
+			"EUR/USD/GBP/USD": 0.62,
+		},
+		SnapshotTime: snapshotTime,
+	}
+
+	msg, err := SnapshotToProto(snapshot)
+	if err != nil {
+		t.Fatalf("SnapshotToProto: %v", err)
+	}
+	// Go through the wire encoding too
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("proto.Marshal: %v", err)
+	}
+	var decoded pb.MarketSnapshot
+	if err := proto.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("proto.Unmarshal: %v", err)
+	}
+
+	got, err := SnapshotFromProto(&decoded, snapshotTime)
+	if err != nil {
+		t.Fatalf("SnapshotFromProto: %v", err)
+	}
+	// Quote timestamps decode in the local zone
+	for pair, spot := range got.SpotRates {
+		spot.Timestamp = spot.Timestamp.UTC()
+		got.SpotRates[pair] = spot
+	}
+
+	for pair, want := range snapshot.SpotRates {
+		if !reflect.DeepEqual(got.SpotRates[pair], want) {
+			t.Errorf("spot %s = %+v, want %+v", pair, got.SpotRates[pair], want)
+		}
+	}
+	for currency, want := range snapshot.DiscountCurves {
+		if !reflect.DeepEqual(got.DiscountCurves[currency], want) {
+			t.Errorf("curve %s = %+v, want %+v", currency, got.DiscountCurves[currency], want)
+		}
+	}
+	for pair, want := range snapshot.VolSurfaces {
+		if !reflect.DeepEqual(got.VolSurfaces[pair], want) {
+			t.Errorf("surface %s = %+v, want %+v", pair, got.VolSurfaces[pair], want)
+		}
+	}
+	if !reflect.DeepEqual(got, snapshot) {
+		t.Errorf("round trip = %+v, want %+v", got, snapshot)
+	}
+}
+
+func TestSnapshotToProtoRejectsInvertedFits(t *testing.T) {
+	reference := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC)
+	snapshot := market.MarketSnapshot{
+		VolSurfaces: map[string]market.VolSurface{
+			"USD/EUR": {
+				Pair:          "USD/EUR",
+				ReferenceDate: reference,
+				Interpolation: market.VolInterpSABR,
+				Fits: []market.SmileFit{{
+					Maturity: expiry, Forward: 1.1, Inverted: true,
+					SABR: &market.SABRParams{Alpha: 0.08, Beta: 0.5, Rho: -0.2, Nu: 0.6},
+				}},
+			},
+		},
+	}
+
+	_, err := SnapshotToProto(snapshot)
+	if err == nil {
+		t.Fatal("SnapshotToProto accepted an inverted fit")
+	}
+	if want := "vol_surfaces[USD/EUR]: vol_grid.fits[0]: fit for 2026-06-02 is inverted"; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+}
@@ -8,6 +8,11 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/market"
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
 )
 
 // PricerClient wraps the gRPC client for the FX pricing service
@@ -68,43 +73,156 @@ func (c *PricerClient) IsConnected() bool {
 }
 
 // PriceRequest sends a price request to the service
-// NOTE: This is a placeholder until protobuf types are generated
-func (c *PricerClient) PriceRequest(ctx context.Context) error {
+func (c *PricerClient) PriceRequest(ctx context.Context, contract models.Contract, snapshot market.MarketSnapshot, params *pb.PricingParams) (*pb.PriceResponse, error) {
 	if !c.IsConnected() {
-		return fmt.Errorf("client not connected")
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	valuationDate, err := time.Parse(dateLayout, params.GetValuationDate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid valuation date %q: %w", params.GetValuationDate(), err)
+	}
+
+	if err := models.Validate(contract, valuationDate); err != nil {
+		return nil, err
+	}
+
+	// Fail fast on missing market data rather than inside the pricing service
+	deps, err := market.AnalyzeDependencies(contract)
+	if err != nil {
+		return nil, err
+	}
+	if err := deps.Check(snapshot); err != nil {
+		return nil, err
+	}
+	// Surfaces admitting static arbitrage imply negative densities in the pricer
+	if err := deps.CheckArbitrage(snapshot); err != nil {
+		return nil, err
+	}
+
+	contractMsg, err := ContractToProto(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode contract: %w", err)
+	}
+
+	marketMsg, err := SnapshotToProto(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode market snapshot: %w", err)
 	}
 
-	c.logger.Info("price request placeholder - awaiting protobuf generation")
-	// TODO: Implement once proto files are generated:
-	// client := pb.NewFXPricerClient(c.conn)
-	// resp, err := client.Price(ctx, &pb.PriceRequest{...})
-	return fmt.Errorf("not implemented: awaiting protobuf schema generation")
+	c.logger.Debug("sending price request",
+		zap.String("valuation_date", params.GetValuationDate()),
+		zap.String("numeraire", params.GetNumeraire().String()),
+		zap.String("model", params.GetModel().String()),
+		zap.String("contract", contract.String()),
+	)
+
+	resp, err := pb.NewFXPricerClient(c.conn).Price(ctx, &pb.PriceRequest{
+		Contract: contractMsg,
+		Market:   marketMsg,
+		Params:   params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("price request failed: %w", err)
+	}
+
+	if resp.GetError() != "" {
+		return resp, fmt.Errorf("pricing service error: %s", resp.GetError())
+	}
+
+	c.logger.Info("price received",
+		zap.Float64("price", resp.GetPrice()),
+		zap.String("numeraire", resp.GetNumeraire()),
+		zap.Float64("computation_time_ms", resp.GetComputationTimeMs()),
+	)
+
+	return resp, nil
 }
 
-// UpdateMarket sends market data updates to the service
-// NOTE: This is a placeholder until protobuf types are generated
-func (c *PricerClient) UpdateMarket(ctx context.Context) error {
+// UpdateMarket sends market data updates to the service. Two-way spot
+// updates are checked for bid <= ask and get their mid rate filled in, and
+// calibrated vol updates are checked for static arbitrage. Defaults are
+// filled in on a copy; the caller's update is left unchanged.
+func (c *PricerClient) UpdateMarket(ctx context.Context, update *pb.MarketUpdate) (*pb.Ack, error) {
 	if !c.IsConnected() {
-		return fmt.Errorf("client not connected")
+		return nil, fmt.Errorf("client not connected")
+	}
+	update = proto.Clone(update).(*pb.MarketUpdate)
+
+	if spot := update.GetSpotUpdate(); spot != nil {
+		if spot.GetBid() != 0 || spot.GetAsk() != 0 {
+			if err := market.ValidateQuote(spot.GetBid(), spot.GetAsk()); err != nil {
+				return nil, fmt.Errorf("invalid spot update for %s: %w", spot.GetPair(), err)
+			}
+			if spot.GetRate() == 0 {
+				spot.Rate = (spot.GetBid() + spot.GetAsk()) / 2
+			}
+		}
+		if spot.GetRate() <= 0 {
+			return nil, fmt.Errorf("invalid spot update for %s: rate must be positive", spot.GetPair())
+		}
+	}
+
+	if vol := update.GetVolUpdate(); vol != nil {
+		surface, err := VolSurfaceFromProto(vol.GetPair(), vol.GetSurface())
+		if err != nil {
+			return nil, fmt.Errorf("invalid vol update for %s: %w", vol.GetPair(), err)
+		}
+		// Calibrated surfaces carry their forwards; plain grids are checked
+		// against spot and curves when priced
+		if surface.Interpolation == market.VolInterpSABR || surface.Interpolation == market.VolInterpSVI {
+			if err := surface.CheckArbitrage(market.SmileMarket{}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if update.GetTimestampMs() == 0 {
+		update.TimestampMs = time.Now().UnixMilli()
+	}
+
+	ack, err := pb.NewFXPricerClient(c.conn).UpdateMarket(ctx, update)
+	if err != nil {
+		return nil, fmt.Errorf("market update failed: %w", err)
 	}
 
-	c.logger.Info("market update placeholder - awaiting protobuf generation")
-	// TODO: Implement once proto files are generated:
-	// client := pb.NewFXPricerClient(c.conn)
-	// resp, err := client.UpdateMarket(ctx, &pb.MarketUpdate{...})
-	return fmt.Errorf("not implemented: awaiting protobuf schema generation")
+	if !ack.GetSuccess() {
+		return ack, fmt.Errorf("market update rejected: %s", ack.GetMessage())
+	}
+
+	c.logger.Info("market update acknowledged",
+		zap.String("message", ack.GetMessage()),
+		zap.Int64("timestamp_ms", ack.GetTimestampMs()),
+	)
+
+	return ack, nil
 }
 
 // HealthCheck queries the health status of the pricing service
-// NOTE: This is a placeholder until protobuf types are generated
-func (c *PricerClient) HealthCheck(ctx context.Context) error {
+func (c *PricerClient) HealthCheck(ctx context.Context) (*pb.HealthStatus, error) {
 	if !c.IsConnected() {
-		return fmt.Errorf("client not connected")
+		return nil, fmt.Errorf("client not connected")
+	}
+
+	status, err := pb.NewFXPricerClient(c.conn).Health(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("health check failed: %w", err)
 	}
 
-	c.logger.Info("health check placeholder - awaiting protobuf generation")
-	// TODO: Implement once proto files are generated:
-	// client := pb.NewFXPricerClient(c.conn)
-	// resp, err := client.Health(ctx, &pb.Empty{})
-	return fmt.Errorf("not implemented: awaiting protobuf schema generation")
+	c.logger.Info("health check completed",
+		zap.Bool("healthy", status.GetHealthy()),
+		zap.String("version", status.GetVersion()),
+		zap.Int64("uptime_seconds", status.GetUptimeSeconds()),
+	)
+
+	return status, nil
+}
+
+// NewPricingParams builds the valuation parameters sent with a price request
+func NewPricingParams(valuationDate time.Time, numeraire pb.Currency, model pb.PricingModel) *pb.PricingParams {
+	return &pb.PricingParams{
+		ValuationDate: valuationDate.Format(dateLayout),
+		Numeraire:     numeraire,
+		Model:         model,
+	}
 }
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/leonc/ficc-pricer/market-gateway/pkg/proto"
+)
+
+// recordingPricer acknowledges every market update and keeps the last one
+type recordingPricer struct {
+	pb.UnimplementedFXPricerServer
+	received *pb.MarketUpdate
+}
+
+func (s *recordingPricer) UpdateMarket(_ context.Context, update *pb.MarketUpdate) (*pb.Ack, error) {
+	s.received = update
+	return &pb.Ack{Success: true}, nil
+}
+
+// connectInMemory returns a client connected to server over an in-memory listener
+func connectInMemory(t *testing.T, server pb.FXPricerServer) *PricerClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterFXPricerServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewPricerClient("bufnet", zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.conn = conn
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestUpdateMarketLeavesTheCallersUpdateUnchanged(t *testing.T) {
+	server := &recordingPricer{}
+	client := connectInMemory(t, server)
+
+	update := &pb.MarketUpdate{UpdateType: &pb.MarketUpdate_SpotUpdate{SpotUpdate: &pb.SpotUpdate{
+		Pair: "EUR/USD",
+		Bid:  1.0999,
+		Ask:  1.1001,
+	}}}
+	original := proto.Clone(update)
+
+	if _, err := client.UpdateMarket(context.Background(), update); err != nil {
+		t.Fatalf("UpdateMarket: %v", err)
+	}
+	if !proto.Equal(update, original) {
+		t.Errorf("caller's update was modified: %v, want %v", update, original)
+	}
+
+	// The service receives the filled-in copy
+	sent := server.received.GetSpotUpdate()
+	if sent.GetRate() != (1.0999+1.1001)/2 {
+		t.Errorf("sent rate = %g, want the mid", sent.GetRate())
+	}
+	if server.received.GetTimestampMs() == 0 {
+		t.Error("sent update has no timestamp")
+	}
+}
@@ -13,6 +13,7 @@ type Config struct {
 	Server   ServerConfig   `mapstructure:"server"`
 	Logging  LoggingConfig  `mapstructure:"logging"`
 	Market   MarketConfig   `mapstructure:"market"`
+	Currencies []CurrencyConfig `mapstructure:"currencies"` // added to or overriding the built-in registry
 }
 
 // ServerConfig holds gRPC server connection settings
@@ -37,6 +38,18 @@ type MarketConfig struct {
 	DefaultVolatility  float64 `mapstructure:"default_volatility"`
 	DefaultRate        float64 `mapstructure:"default_rate"`
 	UpdateIntervalMs   int     `mapstructure:"update_interval_ms"`
+	VehicleCurrency    string  `mapstructure:"vehicle_currency"` // currency crosses are triangulated through
+	CrossRouting       string  `mapstructure:"cross_routing"`    // vehicle, shortest_path, disabled
+	ArbitragePolicy    string  `mapstructure:"arbitrage_policy"` // reject, warn, off
+}
+
+// CurrencyConfig holds the reference data of one currency
+type CurrencyConfig struct {
+	Code           string `mapstructure:"code"`            // ISO 4217 alphabetic code
+	Numeric        int    `mapstructure:"numeric"`         // ISO 4217 numeric code, 0 if none
+	MinorUnits     int    `mapstructure:"minor_units"`     // decimal places
+	SettlementDays int    `mapstructure:"settlement_days"` // spot lag in business days
+	Calendar       string `mapstructure:"calendar"`        // holiday calendar ID
 }
 
 var (
@@ -70,19 +83,25 @@ func getDefaultConfig() *Config {
 			DefaultVolatility: 0.12,
 			DefaultRate:       0.05,
 			UpdateIntervalMs:  1000,
+			VehicleCurrency:   "USD",
+			CrossRouting:      "vehicle",
+			ArbitragePolicy:   "reject",
 		},
 	}
 }
 
 // LoadConfig loads configuration from a file
 func LoadConfig(configPath string) error {
+	// Start from defaults so the file only needs to override what it sets
+	config = getDefaultConfig()
+
 	viper.SetConfigFile(configPath)
 
 	if err := viper.ReadInConfig(); err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := viper.Unmarshal(&config); err != nil {
+	if err := viper.Unmarshal(config); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
@@ -110,7 +129,7 @@ func LoadDefaultConfig() {
 
 	// Try to read config (ignore error if not found)
 	if err := viper.ReadInConfig(); err == nil {
-		_ = viper.Unmarshal(&config)
+		_ = viper.Unmarshal(config)
 	}
 }
 
@@ -174,5 +193,17 @@ market:
   default_volatility: 0.12  # 12%
   default_rate: 0.05        # 5%
   update_interval_ms: 1000  # 1 second
+  vehicle_currency: "USD"   # crosses such as EUR/JPY go through EUR/USD and USD/JPY
+  cross_routing: "vehicle"  # vehicle, shortest_path, disabled
+  arbitrage_policy: "reject" # vol surfaces admitting static arbitrage: reject, warn, off
+
+# Currencies beyond the built-in registry (USD, EUR, GBP, JPY, CHF, AUD, CAD,
+# NZD, NOK, SEK, DKK, MXN, CNH, HKD, SGD), or overrides of their reference data
+currencies:
+  - code: "PLN"
+    numeric: 985
+    minor_units: 2
+    settlement_days: 2
+    calendar: "PLWA"
 `
 }
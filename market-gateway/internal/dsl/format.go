@@ -0,0 +1,127 @@
+package dsl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// Format prints a contract in DSL syntax. Parse(Format(c)) returns a contract
+// Equal to c; nil nodes are printed as <invalid ...>.
+func Format(c models.Contract) string {
+	// The formatter handles nil nodes and never fails
+	s, _ := models.Fold[string](c, formatter{})
+	return s
+}
+
+// formatter folds a contract into its DSL text
+type formatter struct{}
+
+func (formatter) VisitNil(string) (string, error) {
+	return fmt.Sprintf("<invalid %T>", nil), nil
+}
+
+func (formatter) VisitZero(string, models.Zero) (string, error) {
+	return "zero", nil
+}
+
+func (formatter) VisitSpot(_ string, c models.Spot) (string, error) {
+	return fmt.Sprintf("spot %s", formatPair(c.Domestic, c.Foreign)), nil
+}
+
+func (formatter) VisitForward(_ string, c models.Forward) (string, error) {
+	return fmt.Sprintf("forward %s F=%s T=%s",
+		formatPair(c.Domestic, c.Foreign), formatNumber(c.FixedRate), c.Maturity.Format(dateLayout)), nil
+}
+
+func (formatter) VisitEurOption(_ string, c models.EurOption) (string, error) {
+	return fmt.Sprintf("%s %s K=%s T=%s", strings.ToLower(c.Type.String()),
+		formatPair(c.Domestic, c.Foreign), formatNumber(c.Strike), c.Maturity.Format(dateLayout)), nil
+}
+
+func (formatter) VisitZCB(_ string, c models.ZCB) (string, error) {
+	return fmt.Sprintf("zcb %s T=%s", c.Currency, c.Maturity.Format(dateLayout)), nil
+}
+
+func (formatter) VisitScale(_ string, c models.Scale, contract string) (string, error) {
+	return fmt.Sprintf("scale %s %s", formatNumber(c.Notional), formatTerm(c.Contract, contract)), nil
+}
+
+func (formatter) VisitCombine(_ string, c models.Combine, left, right string) (string, error) {
+	// Combine nests to the right, so only a Combine on the left needs parentheses
+	if _, ok := c.Left.(models.Combine); ok {
+		left = "(" + left + ")"
+	}
+	return left + " + " + right, nil
+}
+
+func (formatter) VisitWhen(_ string, c models.When, contract string) (string, error) {
+	var b strings.Builder
+	b.WriteString("when ")
+	formatCondition(&b, c.Condition)
+	b.WriteString(" ")
+	b.WriteString(formatTerm(c.Contract, contract))
+	return b.String(), nil
+}
+
+func (formatter) VisitNamed(_ string, c models.Named, contract string) (string, error) {
+	return fmt.Sprintf("named %s %s", strconv.Quote(c.Name), formatTerm(c.Contract, contract)), nil
+}
+
+// formatTerm parenthesizes the formatted operand of scale, when or named
+// when it is a Combine
+func formatTerm(c models.Contract, formatted string) string {
+	if _, ok := c.(models.Combine); ok {
+		return "(" + formatted + ")"
+	}
+	return formatted
+}
+
+func formatCondition(b *strings.Builder, o models.BoolObservable) {
+	switch o := o.(type) {
+	case models.ConstBool:
+		b.WriteString(strconv.FormatBool(o.Value))
+
+	case models.Barrier:
+		// Parenthesized so the underlying reads apart from the guarded contract
+		fmt.Fprintf(b, "(barrier %s %s ", strings.ToLower(o.Direction.String()), formatNumber(o.Level))
+		formatValue(b, o.Underlying)
+		b.WriteString(")")
+
+	default:
+		fmt.Fprintf(b, "<invalid %T>", o)
+	}
+}
+
+func formatValue(b *strings.Builder, o models.DoubleObservable) {
+	switch o := o.(type) {
+	case models.ConstDouble:
+		b.WriteString(formatNumber(o.Value))
+
+	case models.SpotRate:
+		fmt.Fprintf(b, "spot %s", formatPair(o.Domestic, o.Foreign))
+
+	case models.FwdRate:
+		fmt.Fprintf(b, "fwd %s T=%s", formatPair(o.Domestic, o.Foreign), o.Maturity.Format(dateLayout))
+
+	default:
+		fmt.Fprintf(b, "<invalid %T>", o)
+	}
+}
+
+// formatPair prints a pair as FOREIGN/DOMESTIC
+func formatPair(domestic, foreign models.Currency) string {
+	return models.NewCurrencyPair(foreign, domestic).String()
+}
+
+// formatNumber prints the shortest representation that parses back to x
+func formatNumber(x float64) string {
+	abs := math.Abs(x)
+	if x == 0 || (abs >= 1e-6 && abs < 1e15) {
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
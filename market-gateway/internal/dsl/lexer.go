@@ -0,0 +1,245 @@
+package dsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind classifies a lexical token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokDate
+	tokString
+	tokLParen
+	tokRParen
+	tokPlus
+	tokMinus
+	tokSlash
+	tokEquals
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of input"
+	case tokIdent:
+		return "identifier"
+	case tokNumber:
+		return "number"
+	case tokDate:
+		return "date"
+	case tokString:
+		return "string"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokPlus:
+		return "'+'"
+	case tokMinus:
+		return "'-'"
+	case tokSlash:
+		return "'/'"
+	case tokEquals:
+		return "'='"
+	}
+	return "unknown token"
+}
+
+// Position is a 1-based line and column in the source text
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+type token struct {
+	kind tokenKind
+	text string // raw text; unquoted value for strings
+	pos  Position
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF, tokLParen, tokRParen, tokPlus, tokMinus, tokSlash, tokEquals:
+		return t.kind.String()
+	}
+	return fmt.Sprintf("%s %q", t.kind, t.text)
+}
+
+type lexer struct {
+	src  []rune
+	off  int
+	line int
+	col  int
+}
+
+// tokenize splits the source into tokens, ending with tokEOF
+func tokenize(src string) ([]token, error) {
+	l := &lexer{src: []rune(src), line: 1, col: 1}
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) peek(ahead int) rune {
+	if l.off+ahead >= len(l.src) {
+		return 0
+	}
+	return l.src[l.off+ahead]
+}
+
+func (l *lexer) advance() rune {
+	r := l.src[l.off]
+	l.off++
+	if r == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return r
+}
+
+func (l *lexer) next() (token, error) {
+	// Skip whitespace and '#' comments
+	for l.off < len(l.src) {
+		r := l.peek(0)
+		if r == '#' {
+			for l.off < len(l.src) && l.peek(0) != '\n' {
+				l.advance()
+			}
+			continue
+		}
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.advance()
+	}
+
+	pos := Position{Line: l.line, Column: l.col}
+	if l.off >= len(l.src) {
+		return token{kind: tokEOF, pos: pos}, nil
+	}
+
+	single := map[rune]tokenKind{
+		'(': tokLParen,
+		')': tokRParen,
+		'+': tokPlus,
+		'-': tokMinus,
+		'/': tokSlash,
+		'=': tokEquals,
+	}
+
+	r := l.peek(0)
+	switch {
+	case single[r] != 0:
+		l.advance()
+		return token{kind: single[r], text: string(r), pos: pos}, nil
+
+	case r == '"':
+		return l.quoted(pos)
+
+	case l.isDate():
+		start := l.off
+		for i := 0; i < 10; i++ {
+			l.advance()
+		}
+		return token{kind: tokDate, text: string(l.src[start:l.off]), pos: pos}, nil
+
+	case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(l.peek(1))):
+		return l.number(pos), nil
+
+	case unicode.IsLetter(r) || r == '_':
+		start := l.off
+		for l.off < len(l.src) && (unicode.IsLetter(l.peek(0)) || unicode.IsDigit(l.peek(0)) || l.peek(0) == '_') {
+			l.advance()
+		}
+		return token{kind: tokIdent, text: string(l.src[start:l.off]), pos: pos}, nil
+	}
+
+	return token{}, &ParseError{Pos: pos, Message: fmt.Sprintf("unexpected character %q", r)}
+}
+
+// isDate reports whether the input at the cursor looks like YYYY-MM-DD
+func (l *lexer) isDate() bool {
+	for i := 0; i < 10; i++ {
+		r := l.peek(i)
+		if i == 4 || i == 7 {
+			if r != '-' {
+				return false
+			}
+		} else if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	next := l.peek(10)
+	return !unicode.IsDigit(next) && !unicode.IsLetter(next)
+}
+
+func (l *lexer) number(pos Position) token {
+	start := l.off
+	for unicode.IsDigit(l.peek(0)) || l.peek(0) == '.' {
+		l.advance()
+	}
+	if e := l.peek(0); e == 'e' || e == 'E' {
+		sign := l.peek(1)
+		if unicode.IsDigit(sign) || ((sign == '+' || sign == '-') && unicode.IsDigit(l.peek(2))) {
+			l.advance()
+			if sign == '+' || sign == '-' {
+				l.advance()
+			}
+			for unicode.IsDigit(l.peek(0)) {
+				l.advance()
+			}
+		}
+	}
+	return token{kind: tokNumber, text: string(l.src[start:l.off]), pos: pos}
+}
+
+func (l *lexer) quoted(pos Position) (token, error) {
+	start := l.off
+	l.advance() // opening quote
+	for {
+		if l.off >= len(l.src) || l.peek(0) == '\n' {
+			return token{}, &ParseError{Pos: pos, Message: "unterminated string"}
+		}
+		r := l.advance()
+		if r == '\\' && l.off < len(l.src) {
+			l.advance()
+			continue
+		}
+		if r == '"' {
+			break
+		}
+	}
+
+	raw := string(l.src[start:l.off])
+	value, err := strconv.Unquote(raw)
+	if err != nil {
+		return token{}, &ParseError{Pos: pos, Message: fmt.Sprintf("invalid string %s", raw)}
+	}
+	return token{kind: tokString, text: value, pos: pos}, nil
+}
+
+// isKeyword reports whether an identifier token matches a keyword, ignoring case
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, keyword)
+}
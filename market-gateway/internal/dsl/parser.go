@@ -0,0 +1,430 @@
+// Package dsl implements a small textual language for contracts, used by the
+// CLI and configuration files. For example:
+//
+//	scale 1e6 (call EUR/USD K=1.15 T=2025-12-31)
+//	  + scale -1e6 (put EUR/USD K=1.05 T=2025-12-31)
+//
+// Grammar (keywords are case-insensitive, '#' starts a comment):
+//
+//	portfolio := term { ("+" | "-") term }
+//	term      := "scale" number term
+//	           | "named" string term
+//	           | "when" condition term
+//	           | atom
+//	atom      := "(" portfolio ")"
+//	           | "zero"
+//	           | "spot" pair
+//	           | "forward" pair "F=" number "T=" date
+//	           | ("call" | "put") pair "K=" number "T=" date
+//	           | "zcb" currency "T=" date
+//	condition := "true" | "false"
+//	           | "barrier" ("up" | "down") number value
+//	           | "(" condition ")"
+//	value     := "spot" pair | "fwd" pair "T=" date | number | "(" value ")"
+//	pair      := currency "/" currency     (FOREIGN/DOMESTIC, e.g. EUR/USD)
+//	date      := YYYY-MM-DD
+//
+// Keyword arguments (K=, T=, F=) may appear in any order. "a + b + c" nests
+// to the right like Haskell's (<>), and "a - b" is "a + scale -1 b".
+package dsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+const dateLayout = "2006-01-02"
+
+// ParseError reports a syntax or semantic error at a position in the source
+type ParseError struct {
+	Pos     Position
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// Parse parses a contract expression
+func Parse(src string) (models.Contract, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	c, err := p.portfolio()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "expected '+', '-' or end of input, found %s", tok.describe())
+	}
+	return c, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Pos: tok.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expect(kind tokenKind) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, p.errorf(tok, "expected %s, found %s", kind, tok.describe())
+	}
+	return tok, nil
+}
+
+func (p *parser) portfolio() (models.Contract, error) {
+	first, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	legs := []models.Contract{first}
+	for {
+		op := p.peek()
+		if op.kind != tokPlus && op.kind != tokMinus {
+			break
+		}
+		p.next()
+
+		leg, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		if op.kind == tokMinus {
+			leg = models.NewScale(-1, leg)
+		}
+		legs = append(legs, leg)
+	}
+
+	return models.CombineAll(legs...), nil
+}
+
+func (p *parser) term() (models.Contract, error) {
+	tok := p.peek()
+	switch {
+	case isKeyword(tok, "scale"):
+		p.next()
+		notional, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		inner, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewScale(notional, inner), nil
+
+	case isKeyword(tok, "named"):
+		p.next()
+		name, err := p.expect(tokString)
+		if err != nil {
+			return nil, err
+		}
+		inner, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewNamed(name.text, inner), nil
+
+	case isKeyword(tok, "when"):
+		p.next()
+		condition, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		inner, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewWhen(condition, inner), nil
+	}
+
+	return p.atom()
+}
+
+func (p *parser) atom() (models.Contract, error) {
+	tok := p.next()
+	switch {
+	case tok.kind == tokLParen:
+		inner, err := p.portfolio()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return inner, nil
+
+	case isKeyword(tok, "zero"):
+		return models.Zero{}, nil
+
+	case isKeyword(tok, "spot"):
+		domestic, foreign, err := p.pair()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewSpot(domestic, foreign), nil
+
+	case isKeyword(tok, "forward"):
+		domestic, foreign, err := p.pair()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.keywordArgs(tok, "F", "T")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewForward(args.dates["T"], args.numbers["F"], domestic, foreign), nil
+
+	case isKeyword(tok, "call"), isKeyword(tok, "put"):
+		optType := models.Call
+		if isKeyword(tok, "put") {
+			optType = models.Put
+		}
+		domestic, foreign, err := p.pair()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.keywordArgs(tok, "K", "T")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewEurOption(optType, args.numbers["K"], args.dates["T"], domestic, foreign), nil
+
+	case isKeyword(tok, "zcb"):
+		currency, err := p.currency()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.keywordArgs(tok, "T")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewZCB(currency, args.dates["T"]), nil
+	}
+
+	return nil, p.errorf(tok, "expected a contract, found %s", tok.describe())
+}
+
+func (p *parser) condition() (models.BoolObservable, error) {
+	tok := p.next()
+	switch {
+	case isKeyword(tok, "true"):
+		return models.NewConstBool(true), nil
+
+	case isKeyword(tok, "false"):
+		return models.NewConstBool(false), nil
+
+	case isKeyword(tok, "barrier"):
+		dirTok := p.next()
+		var direction models.Direction
+		switch {
+		case isKeyword(dirTok, "up"):
+			direction = models.Up
+		case isKeyword(dirTok, "down"):
+			direction = models.Down
+		default:
+			return nil, p.errorf(dirTok, "expected 'up' or 'down', found %s", dirTok.describe())
+		}
+		level, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		underlying, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewBarrier(direction, level, underlying), nil
+
+	case tok.kind == tokLParen:
+		inner, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	return nil, p.errorf(tok, "expected a condition, found %s", tok.describe())
+}
+
+func (p *parser) value() (models.DoubleObservable, error) {
+	tok := p.peek()
+	switch {
+	case isKeyword(tok, "spot"):
+		p.next()
+		domestic, foreign, err := p.pair()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewSpotRate(domestic, foreign), nil
+
+	case isKeyword(tok, "fwd"):
+		p.next()
+		domestic, foreign, err := p.pair()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.keywordArgs(tok, "T")
+		if err != nil {
+			return nil, err
+		}
+		return models.NewFwdRate(domestic, foreign, args.dates["T"]), nil
+
+	case tok.kind == tokNumber, tok.kind == tokMinus:
+		value, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		return models.NewConstDouble(value), nil
+
+	case tok.kind == tokLParen:
+		p.next()
+		inner, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	p.next()
+	return nil, p.errorf(tok, "expected an observable value, found %s", tok.describe())
+}
+
+// number parses an optionally signed number
+func (p *parser) number() (float64, error) {
+	sign := 1.0
+	if p.peek().kind == tokMinus {
+		p.next()
+		sign = -1
+	}
+
+	tok, err := p.expect(tokNumber)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(tok.text, 64)
+	if err != nil {
+		return 0, p.errorf(tok, "invalid number %q", tok.text)
+	}
+	return sign * value, nil
+}
+
+func (p *parser) currency() (models.Currency, error) {
+	tok, err := p.expect(tokIdent)
+	if err != nil {
+		return 0, err
+	}
+	c, err := models.ParseCurrency(tok.text)
+	if err != nil {
+		return 0, p.errorf(tok, "%v", err)
+	}
+	return c, nil
+}
+
+// pair parses FOREIGN/DOMESTIC and returns (domestic, foreign)
+func (p *parser) pair() (models.Currency, models.Currency, error) {
+	foreign, err := p.currency()
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := p.expect(tokSlash); err != nil {
+		return 0, 0, err
+	}
+	domestic, err := p.currency()
+	if err != nil {
+		return 0, 0, err
+	}
+	return domestic, foreign, nil
+}
+
+type keywordArgs struct {
+	numbers map[string]float64
+	dates   map[string]time.Time
+}
+
+// keywordArgs parses NAME=value arguments. "T" is always a date, every other
+// name a number; each listed name must appear exactly once.
+func (p *parser) keywordArgs(owner token, names ...string) (keywordArgs, error) {
+	args := keywordArgs{numbers: map[string]float64{}, dates: map[string]time.Time{}}
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	seen := map[string]bool{}
+	for len(seen) < len(names) {
+		tok := p.peek()
+		if tok.kind != tokIdent || !wanted[strings.ToUpper(tok.text)] {
+			var missing []string
+			for _, name := range names {
+				if !seen[name] {
+					missing = append(missing, name+"=")
+				}
+			}
+			return args, p.errorf(tok, "%s: expected %s, found %s",
+				strings.ToLower(owner.text), strings.Join(missing, " "), tok.describe())
+		}
+		p.next()
+
+		name := strings.ToUpper(tok.text)
+		if seen[name] {
+			return args, p.errorf(tok, "duplicate argument %s=", name)
+		}
+		seen[name] = true
+
+		if _, err := p.expect(tokEquals); err != nil {
+			return args, err
+		}
+
+		if name == "T" {
+			dateTok, err := p.expect(tokDate)
+			if err != nil {
+				return args, err
+			}
+			date, err := time.Parse(dateLayout, dateTok.text)
+			if err != nil {
+				return args, p.errorf(dateTok, "invalid date %q", dateTok.text)
+			}
+			args.dates[name] = date
+			continue
+		}
+
+		value, err := p.number()
+		if err != nil {
+			return args, err
+		}
+		args.numbers[name] = value
+	}
+
+	return args, nil
+}
@@ -0,0 +1,167 @@
+package dsl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+var (
+	maturity = time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+	eurCall  = models.NewCallOption(1.15, maturity, models.USD, models.EUR)
+	eurPut   = models.NewPutOption(1.05, maturity, models.USD, models.EUR)
+)
+
+func mustParse(t *testing.T, src string) models.Contract {
+	t.Helper()
+	c, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", src, err)
+	}
+	return c
+}
+
+func TestParseRequestExample(t *testing.T) {
+	src := "scale 1e6 (call EUR/USD K=1.15 T=2025-12-31) + scale -1e6 (put EUR/USD K=1.05 T=2025-12-31)"
+	want := models.NewCombine(models.NewScale(1e6, eurCall), models.NewScale(-1e6, eurPut))
+
+	got := mustParse(t, src)
+	if !models.Equal(got, want) {
+		t.Fatalf("Parse = %s, want %s", got, want)
+	}
+	formatted := Format(got)
+	if again := mustParse(t, formatted); !models.Equal(again, got) {
+		t.Errorf("Parse(Format(c)) = %s, want %s (formatted %q)", again, got, formatted)
+	}
+}
+
+func TestFormatRoundTrips(t *testing.T) {
+	barrier := models.NewBarrier(models.Up, 1.2, models.NewSpotRate(models.USD, models.EUR))
+	fwdBarrier := models.NewBarrier(models.Down, 140, models.NewFwdRate(models.JPY, models.USD, maturity))
+
+	tests := map[string]models.Contract{
+		"zero":          models.Zero{},
+		"spot":          models.NewSpot(models.USD, models.EUR),
+		"forward":       models.NewForward(maturity, 1.12, models.USD, models.EUR),
+		"zcb":           models.NewZCB(models.JPY, maturity),
+		"tiny notional": models.NewScale(1e-9, models.NewZCB(models.USD, maturity)),
+		"named":         models.NewNamed("strangle \"wide\"", models.NewCombine(eurCall, eurPut)),
+		"when barrier":  models.NewWhen(barrier, eurCall),
+		"when fwd":      models.NewWhen(fwdBarrier, models.NewScale(-2, eurPut)),
+		"when const":    models.NewWhen(models.NewConstBool(false), models.Zero{}),
+		"left nested":   models.NewCombine(models.NewCombine(eurCall, eurPut), models.Zero{}),
+		"scale combine": models.NewScale(3, models.NewCombine(eurCall, eurPut)),
+		"when combine":  models.NewWhen(barrier, models.NewCombine(eurCall, eurPut)),
+		"right nested":  models.CombineAll(eurCall, eurPut, models.NewSpot(models.USD, models.EUR)),
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			formatted := Format(c)
+			got, err := Parse(formatted)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", formatted, err)
+			}
+			if !models.Equal(got, c) {
+				t.Errorf("Parse(%q) = %s, want %s", formatted, got, c)
+			}
+		})
+	}
+}
+
+func TestParseMinusDesugarsToScale(t *testing.T) {
+	got := mustParse(t, "call EUR/USD K=1.15 T=2025-12-31 - put EUR/USD K=1.05 T=2025-12-31")
+	want := models.NewCombine(eurCall, models.NewScale(-1, eurPut))
+	if !models.Equal(got, want) {
+		t.Errorf("a - b = %s, want %s", got, want)
+	}
+}
+
+func TestParseCombineAssociativity(t *testing.T) {
+	a, b, c := "zcb USD T=2025-12-31", "zcb EUR T=2025-12-31", "zcb JPY T=2025-12-31"
+	zcb := func(currency models.Currency) models.Contract { return models.NewZCB(currency, maturity) }
+
+	right := models.NewCombine(zcb(models.USD), models.NewCombine(zcb(models.EUR), zcb(models.JPY)))
+	if got := mustParse(t, a+" + "+b+" + "+c); !models.Equal(got, right) {
+		t.Errorf("a + b + c = %s, want %s", got, right)
+	}
+
+	left := models.NewCombine(models.NewCombine(zcb(models.USD), zcb(models.EUR)), zcb(models.JPY))
+	got := mustParse(t, "("+a+" + "+b+") + "+c)
+	if !models.Equal(got, left) {
+		t.Errorf("(a + b) + c = %s, want %s", got, left)
+	}
+	if formatted := Format(left); !strings.HasPrefix(formatted, "(") {
+		t.Errorf("Format(%s) = %q, want the left Combine parenthesized", left, formatted)
+	}
+}
+
+func TestParseWhenBarrier(t *testing.T) {
+	src := `named "kio" when (barrier up 1.20 spot EUR/USD) call EUR/USD K=1.10 T=2025-12-31`
+	want := models.NewNamed("kio", models.NewWhen(
+		models.NewBarrier(models.Up, 1.2, models.NewSpotRate(models.USD, models.EUR)),
+		models.NewCallOption(1.1, maturity, models.USD, models.EUR),
+	))
+	if got := mustParse(t, src); !models.Equal(got, want) {
+		t.Errorf("Parse = %s, want %s", got, want)
+	}
+
+	got := mustParse(t, "when barrier down -1 (fwd usd/jpy T=2025-12-31) zero")
+	wantDown := models.NewWhen(
+		models.NewBarrier(models.Down, -1, models.NewFwdRate(models.JPY, models.USD, maturity)),
+		models.Zero{},
+	)
+	if !models.Equal(got, wantDown) {
+		t.Errorf("Parse = %s, want %s", got, wantDown)
+	}
+}
+
+func TestParseKeywordArguments(t *testing.T) {
+	for _, src := range []string{
+		"call EUR/USD K=1.15 T=2025-12-31",
+		"call EUR/USD T=2025-12-31 K=1.15",
+		"CALL eur/usd t=2025-12-31 k=1.15",
+	} {
+		if got := mustParse(t, src); !models.Equal(got, eurCall) {
+			t.Errorf("Parse(%q) = %s, want %s", src, got, eurCall)
+		}
+	}
+
+	tests := map[string]string{
+		"call EUR/USD K=1.15 K=1.2 T=2025-12-31": "line 1, column 21: duplicate argument K=",
+		"call EUR/USD K=1.15":                    "line 1, column 20: call: expected T=, found end of input",
+		"forward EUR/USD T=2025-12-31 X=1":       "line 1, column 30: forward: expected F=, found",
+		"zcb USD T=2025-02-30":                   "line 1, column 11: invalid date",
+	}
+	for src, want := range tests {
+		_, err := Parse(src)
+		if err == nil || !strings.HasPrefix(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want prefix %q", src, err, want)
+		}
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	src := "scale 1e6 (call EUR/USD K=1.15 T=2025-12-31)\n" +
+		"  # the put leg\n" +
+		"  + scale -1e6 (put EUR/XXX K=1.05 T=2025-12-31)\n"
+
+	_, err := Parse(src)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Parse error = %v, want a *ParseError", err)
+	}
+	if parseErr.Pos != (Position{Line: 3, Column: 25}) {
+		t.Errorf("position = %s, want line 3, column 25", parseErr.Pos)
+	}
+	if !strings.HasPrefix(err.Error(), "line 3, column 25: unknown currency: XXX") {
+		t.Errorf("error = %q", err)
+	}
+
+	_, err = Parse("scale 2 (zero\n  + zero")
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2, column 9: expected ')', found end of input") {
+		t.Errorf("unclosed parenthesis error = %v", err)
+	}
+}
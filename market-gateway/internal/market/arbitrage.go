@@ -0,0 +1,405 @@
+package market
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// ArbitragePolicy selects what the manager does with vol surfaces that fail
+// the static-arbitrage checks
+type ArbitragePolicy int
+
+const (
+	// ArbitrageReject refuses the update
+	ArbitrageReject ArbitragePolicy = iota
+	// ArbitrageWarn stores the surface and logs the violations
+	ArbitrageWarn
+	// ArbitrageOff skips the checks
+	ArbitrageOff
+)
+
+func (p ArbitragePolicy) String() string {
+	switch p {
+	case ArbitrageReject:
+		return "reject"
+	case ArbitrageWarn:
+		return "warn"
+	case ArbitrageOff:
+		return "off"
+	}
+	return ""
+}
+
+// ParseArbitragePolicy parses "reject", "warn" or "off"
+func ParseArbitragePolicy(s string) (ArbitragePolicy, error) {
+	for _, p := range []ArbitragePolicy{ArbitrageReject, ArbitrageWarn, ArbitrageOff} {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown arbitrage policy: %s", s)
+}
+
+// Static-arbitrage violation kinds
+const (
+	// ArbitrageCalendar is total variance falling with maturity at a fixed
+	// log-moneyness, a negative calendar spread
+	ArbitrageCalendar = "calendar"
+	// ArbitrageButterfly is call prices failing to be decreasing and convex
+	// in strike, or Durrleman's condition failing for SVI: a negative density
+	ArbitrageButterfly = "butterfly"
+)
+
+// ArbitrageViolation is the first point an expiry fails a check at
+type ArbitrageViolation struct {
+	Kind     string
+	Maturity time.Time
+	Strike   float64
+	Detail   string
+}
+
+func (v ArbitrageViolation) String() string {
+	return fmt.Sprintf("%s at %s, strike %.6g: %s", v.Kind, v.Maturity.Format("2006-01-02"), v.Strike, v.Detail)
+}
+
+// ArbitrageError reports every static-arbitrage violation of a surface
+type ArbitrageError struct {
+	Pair       string
+	Violations []ArbitrageViolation
+}
+
+func (e *ArbitrageError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		violations[i] = v.String()
+	}
+	return fmt.Sprintf("vol surface %s admits static arbitrage: %s", e.Pair, strings.Join(violations, "; "))
+}
+
+// ErrNoForward is returned when a plain grid is checked without the spot and
+// discount curves its forwards come from. Callers skip the check rather than
+// reject the surface.
+var ErrNoForward = errors.New("checking for arbitrage needs the spot and both discount curves")
+
+// arbitrageTolerance absorbs rounding in the checks
+const arbitrageTolerance = 1e-9
+
+// CheckArbitrage checks a grid or calibrated surface for calendar and
+// butterfly arbitrage, returning an *ArbitrageError listing the violations.
+// Each expiry is sampled over ±4 ATM standard deviations of log-moneyness
+// and at its quoted strikes. Forwards come from the fits or, for plain
+// grids, from mkt; without them the error wraps ErrNoForward. Flat surfaces
+// always pass.
+func (s VolSurface) CheckArbitrage(mkt SmileMarket) error {
+	if !s.IsGrid() {
+		return nil
+	}
+	grid, err := s.grid()
+	if err != nil {
+		return err
+	}
+	mkt.ReferenceDate = s.ReferenceDate
+
+	slices := make([]arbitrageSlice, len(grid.slices))
+	for i, slice := range grid.slices {
+		forward, err := slice.forward(mkt)
+		if err != nil {
+			return fmt.Errorf("vol surface %s: %s: %w", s.Pair, slice.maturity.Format("2006-01-02"), err)
+		}
+		slices[i] = arbitrageSlice{volSlice: slice, forward: forward}
+		slices[i].moneyness = slices[i].sampleMoneyness()
+	}
+
+	var violations []ArbitrageViolation
+	for i, slice := range slices {
+		if v, ok := slice.checkButterfly(); ok {
+			violations = append(violations, v)
+		}
+		if i > 0 {
+			if v, ok := checkCalendar(slices[i-1], slice); ok {
+				violations = append(violations, v)
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &ArbitrageError{Pair: s.Pair, Violations: violations}
+	}
+	return nil
+}
+
+// CheckArbitrage checks every grid surface of the snapshot, taking forwards
+// from the snapshot's spots and curves. Plain grids whose pair lacks a spot or
+// curve are skipped.
+func (s MarketSnapshot) CheckArbitrage() error {
+	pairs := make([]string, 0, len(s.VolSurfaces))
+	for pair := range s.VolSurfaces {
+		pairs = append(pairs, pair)
+	}
+	return s.checkArbitrage(pairs)
+}
+
+// CheckArbitrage checks the snapshot's surfaces for the dependencies' vol
+// pairs only, in whichever orientation they are stored, so unrelated
+// surfaces cannot fail a price request. Surfaces are skipped as for
+// MarketSnapshot.CheckArbitrage.
+func (d Dependencies) CheckArbitrage(snapshot MarketSnapshot) error {
+	var pairs []string
+	for _, surface := range d.VolSurfaces {
+		if _, ok := snapshot.VolSurfaces[surface.Pair]; ok {
+			pairs = append(pairs, surface.Pair)
+			continue
+		}
+		if currencyPair, err := models.ParseCurrencyPair(surface.Pair); err == nil {
+			if inverted := currencyPair.Invert().String(); hasPair(snapshot.VolSurfaces, inverted) {
+				pairs = append(pairs, inverted)
+			}
+		}
+	}
+	return snapshot.checkArbitrage(pairs)
+}
+
+func (s MarketSnapshot) checkArbitrage(pairs []string) error {
+	sort.Strings(pairs)
+
+	var errs []error
+	for _, pair := range pairs {
+		surface := s.VolSurfaces[pair]
+		if !surface.IsGrid() {
+			continue
+		}
+		err := surface.CheckArbitrage(s.smileMarket(pair))
+		if err != nil && !errors.Is(err, ErrNoForward) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// smileMarket collects the spot and curves of a pair, leaving the spot unset
+// if any of them is missing
+func (s MarketSnapshot) smileMarket(pair string) SmileMarket {
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return SmileMarket{}
+	}
+	domestic, okDomestic := s.DiscountCurves[currencyPair.Quote.String()]
+	foreign, okForeign := s.DiscountCurves[currencyPair.Base.String()]
+	if !okDomestic || !okForeign {
+		return SmileMarket{}
+	}
+	if spot, ok := s.SpotRates[currencyPair.String()]; ok {
+		return SmileMarket{Spot: spot.Rate, Domestic: domestic, Foreign: foreign}
+	}
+	if spot, ok := s.SpotRates[currencyPair.Invert().String()]; ok && spot.Rate > 0 {
+		return SmileMarket{Spot: 1 / spot.Rate, Domestic: domestic, Foreign: foreign}
+	}
+	return SmileMarket{}
+}
+
+// SetArbitragePolicy sets how surfaces failing the static-arbitrage checks
+// are handled (rejected by default)
+func (m *Manager) SetArbitragePolicy(policy ArbitragePolicy) error {
+	if policy.String() == "" {
+		return fmt.Errorf("invalid arbitrage policy %d", int(policy))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.arbitragePolicy = policy
+	m.logger.Info("set arbitrage policy", zap.String("policy", policy.String()))
+	return nil
+}
+
+// checkArbitrage applies the arbitrage policy to a surface about to be
+// stored, with forwards from the stored spot and curves. Plain grids stored
+// before their pair's spot or curves are let through with a warning, so
+// updates do not depend on arrival order. The caller must not hold m.mu.
+func (m *Manager) checkArbitrage(surface VolSurface) error {
+	m.mu.RLock()
+	policy := m.arbitragePolicy
+	m.mu.RUnlock()
+	if policy == ArbitrageOff || !surface.IsGrid() {
+		return nil
+	}
+
+	var mkt SmileMarket
+	if pair, err := models.ParseCurrencyPair(surface.Pair); err == nil {
+		spot, spotErr := m.GetSpotRate(pair.String())
+		domestic, domesticErr := m.GetDiscountCurve(pair.Quote.String())
+		foreign, foreignErr := m.GetDiscountCurve(pair.Base.String())
+		if spotErr == nil && domesticErr == nil && foreignErr == nil {
+			mkt = SmileMarket{Spot: spot.Rate, Domestic: domestic, Foreign: foreign}
+		}
+	}
+
+	err := surface.CheckArbitrage(mkt)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrNoForward) {
+		m.logger.Warn("skipped vol surface arbitrage checks",
+			zap.String("pair", surface.Pair),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if policy == ArbitrageReject {
+		return err
+	}
+	m.logger.Warn("vol surface failed arbitrage checks",
+		zap.String("pair", surface.Pair),
+		zap.Error(err),
+	)
+	return nil
+}
+
+// forward returns the forward of the surface's pair at the slice's expiry
+func (s volSlice) forward(mkt SmileMarket) (float64, error) {
+	if s.fit != nil {
+		if s.fit.Inverted {
+			return 1 / s.fit.Forward, nil
+		}
+		return s.fit.Forward, nil
+	}
+	if mkt.Spot <= 0 {
+		return 0, ErrNoForward
+	}
+	forward, _, err := mkt.forward(s.maturity)
+	return forward, err
+}
+
+// arbitrageSlice is an expiry prepared for the arbitrage checks
+type arbitrageSlice struct {
+	volSlice
+	forward   float64
+	moneyness []float64 // sorted sample points k = ln(K/F)
+}
+
+func (s arbitrageSlice) totalVariance(k float64) float64 {
+	vol := s.vol(s.forward * math.Exp(k))
+	return vol * vol * s.t
+}
+
+func (s arbitrageSlice) sampleMoneyness() []float64 {
+	const samples = 81
+	width := 4 * math.Sqrt(s.totalVariance(0))
+	points := make([]float64, 0, samples+len(s.strikes))
+	for i := 0; i < samples; i++ {
+		points = append(points, width*(2*float64(i)/(samples-1)-1))
+	}
+	for _, strike := range s.strikes {
+		points = append(points, math.Log(strike/s.forward))
+	}
+	sort.Float64s(points)
+
+	distinct := points[:1]
+	for _, k := range points[1:] {
+		if k-distinct[len(distinct)-1] > 1e-9 {
+			distinct = append(distinct, k)
+		}
+	}
+	return distinct
+}
+
+// checkButterfly applies Durrleman's condition to SVI fits and checks that
+// undiscounted call prices are decreasing, no steeper than -1 and convex in
+// strike for every other smile, including SVI fits read inverted
+func (s arbitrageSlice) checkButterfly() (ArbitrageViolation, bool) {
+	violation := func(k float64, detail string) (ArbitrageViolation, bool) {
+		return ArbitrageViolation{
+			Kind:     ArbitrageButterfly,
+			Maturity: s.maturity,
+			Strike:   s.forward * math.Exp(k),
+			Detail:   detail,
+		}, true
+	}
+
+	if s.fit != nil && s.fit.SVI != nil && !s.fit.Inverted {
+		for _, k := range s.moneyness {
+			if g := s.fit.SVI.durrleman(k); g < -arbitrageTolerance {
+				return violation(k, fmt.Sprintf("Durrleman's g(k) = %.3g is negative", g))
+			}
+		}
+		return ArbitrageViolation{}, false
+	}
+
+	// Call prices in units of the forward, against strikes x = K/F
+	n := len(s.moneyness)
+	xs := make([]float64, n)
+	calls := make([]float64, n)
+	for i, k := range s.moneyness {
+		xs[i] = math.Exp(k)
+		calls[i] = blackCall(k, s.totalVariance(k))
+	}
+
+	previousSlope := math.Inf(-1)
+	for i := 1; i < n; i++ {
+		slope := (calls[i] - calls[i-1]) / (xs[i] - xs[i-1])
+		switch {
+		case slope > arbitrageTolerance:
+			return violation(s.moneyness[i], fmt.Sprintf("call price rises with strike, slope %.3g", slope))
+		case slope < -1-arbitrageTolerance:
+			return violation(s.moneyness[i], fmt.Sprintf("call price falls faster than the strike rises, slope %.3g", slope))
+		case slope < previousSlope-arbitrageTolerance:
+			return violation(s.moneyness[i-1], fmt.Sprintf("call price is concave in strike, slope falls from %.3g to %.3g",
+				previousSlope, slope))
+		}
+		previousSlope = slope
+	}
+	return ArbitrageViolation{}, false
+}
+
+// checkCalendar checks that total variance does not fall from one expiry to
+// the next at any sampled log-moneyness
+func checkCalendar(earlier, later arbitrageSlice) (ArbitrageViolation, bool) {
+	points := append(append([]float64(nil), earlier.moneyness...), later.moneyness...)
+	sort.Float64s(points)
+	for _, k := range points {
+		wEarlier, wLater := earlier.totalVariance(k), later.totalVariance(k)
+		if wLater < wEarlier-arbitrageTolerance {
+			return ArbitrageViolation{
+				Kind:     ArbitrageCalendar,
+				Maturity: later.maturity,
+				Strike:   later.forward * math.Exp(k),
+				Detail: fmt.Sprintf("total variance falls from %.6g at %s to %.6g",
+					wEarlier, earlier.maturity.Format("2006-01-02"), wLater),
+			}, true
+		}
+	}
+	return ArbitrageViolation{}, false
+}
+
+// blackCall is the undiscounted Black call price in units of the forward at
+// log-moneyness k and total variance w
+func blackCall(k, w float64) float64 {
+	if w <= 0 {
+		return math.Max(1-math.Exp(k), 0)
+	}
+	sd := math.Sqrt(w)
+	d1 := (-k + w/2) / sd
+	return normalCDF(d1) - math.Exp(k)*normalCDF(d1-sd)
+}
+
+// durrleman returns Durrleman's g(k), which must be non-negative for the
+// smile's implied density to be
+func (p SVIParams) durrleman(k float64) float64 {
+	x := k - p.M
+	root := math.Sqrt(x*x + p.Sigma*p.Sigma)
+	w := p.A + p.B*(p.Rho*x+root)
+	if w <= 0 {
+		return math.Inf(-1)
+	}
+	w1 := p.B * (p.Rho + x/root)
+	w2 := p.B * p.Sigma * p.Sigma / (root * root * root)
+	term := 1 - k*w1/(2*w)
+	return term*term - w1*w1/4*(1/w+0.25) + w2/2
+}
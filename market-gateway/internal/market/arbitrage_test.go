@@ -0,0 +1,119 @@
+package market
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// testGrid is a single-expiry grid; butterfly makes the middle vol dip far
+// enough below the wings to give negative call convexity
+func testGrid(pair string, reference time.Time, butterfly bool) VolSurface {
+	maturity := reference.AddDate(1, 0, 0)
+	mid := 0.10
+	if butterfly {
+		mid = 0.01
+	}
+	return VolSurface{
+		Pair:          pair,
+		ReferenceDate: reference,
+		Interpolation: VolInterpLinear,
+		Points: []VolPoint{
+			{Strike: 1.0, Maturity: maturity, Volatility: 0.10},
+			{Strike: 1.1, Maturity: maturity, Volatility: mid},
+			{Strike: 1.2, Maturity: maturity, Volatility: 0.10},
+		},
+	}
+}
+
+func TestUpdateVolGridWithoutForwardsSkipsCheck(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	reference := date(2026, time.January, 2)
+
+	// Even a grid that would fail is stored while its forwards are unknown
+	if err := m.UpdateVolGrid(testGrid("EUR/USD", reference, true)); err != nil {
+		t.Fatalf("UpdateVolGrid on a fresh manager: %v", err)
+	}
+
+	err := testGrid("EUR/USD", reference, false).CheckArbitrage(SmileMarket{})
+	if !errors.Is(err, ErrNoForward) {
+		t.Fatalf("CheckArbitrage without a market = %v, want ErrNoForward", err)
+	}
+}
+
+func TestUpdateVolGridRejectsArbitrage(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateSpotRate("EUR/USD", 1.1); err != nil {
+		t.Fatal(err)
+	}
+	for _, currency := range []string{"EUR", "USD"} {
+		if err := m.UpdateDiscountCurve(currency, 0.02, "continuous"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reference := date(2026, time.January, 2)
+
+	var arbErr *ArbitrageError
+	if err := m.UpdateVolGrid(testGrid("EUR/USD", reference, true)); !errors.As(err, &arbErr) {
+		t.Fatalf("UpdateVolGrid = %v, want an *ArbitrageError", err)
+	}
+	if err := m.UpdateVolGrid(testGrid("EUR/USD", reference, false)); err != nil {
+		t.Fatalf("UpdateVolGrid on a clean grid: %v", err)
+	}
+}
+
+func TestDependenciesCheckArbitrageOnlyChecksOwnSurfaces(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.SetArbitragePolicy(ArbitrageWarn); err != nil {
+		t.Fatal(err)
+	}
+	for pair, rate := range map[string]float64{"EUR/USD": 1.1, "GBP/USD": 1.1} {
+		if err := m.UpdateSpotRate(pair, rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, currency := range []string{"EUR", "USD", "GBP"} {
+		if err := m.UpdateDiscountCurve(currency, 0.02, "continuous"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reference := date(2026, time.January, 2)
+	clean := testGrid("EUR/USD", reference, false)
+	if err := m.UpdateVolGrid(clean); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UpdateVolGrid(testGrid("GBP/USD", reference, true)); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := m.GetSnapshot()
+	if err := snapshot.CheckArbitrage(); err == nil {
+		t.Fatal("snapshot check should report the GBP/USD surface")
+	}
+
+	// The second option looks the EUR/USD surface up as USD/EUR
+	maturity := reference.AddDate(0, 6, 0)
+	for _, option := range []models.Contract{
+		models.NewCallOption(1.1, maturity, models.USD, models.EUR),
+		models.NewCallOption(1/1.1, maturity, models.EUR, models.USD),
+	} {
+		deps, err := AnalyzeDependencies(option)
+		if err != nil {
+			t.Fatalf("AnalyzeDependencies: %v", err)
+		}
+		if err := deps.CheckArbitrage(snapshot); err != nil {
+			t.Errorf("%s: CheckArbitrage = %v, want nil", option, err)
+		}
+	}
+
+	deps, err := AnalyzeDependencies(models.NewCallOption(1.1, maturity, models.USD, models.GBP))
+	if err != nil {
+		t.Fatalf("AnalyzeDependencies: %v", err)
+	}
+	if err := deps.CheckArbitrage(snapshot); err == nil {
+		t.Error("GBP/USD option should fail the arbitrage check")
+	}
+}
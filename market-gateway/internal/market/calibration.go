@@ -0,0 +1,339 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// Parametric smile models. A surface with one of these interpolations is
+// evaluated from its Fits instead of its Points, with the same
+// total-variance interpolation between expiries.
+const (
+	// VolInterpSABR is the Hagan et al. lognormal SABR expansion
+	VolInterpSABR = "SABR"
+	// VolInterpSVI is Gatheral's raw SVI parameterisation of total variance
+	VolInterpSVI = "SVI"
+)
+
+// SABRParams are the SABR parameters of one expiry
+type SABRParams struct {
+	Alpha float64 // initial vol level, > 0
+	Beta  float64 // CEV exponent in [0, 1], fixed during calibration
+	Rho   float64 // spot/vol correlation in (-1, 1)
+	Nu    float64 // vol of vol, >= 0
+}
+
+// SVIParams are the raw SVI parameters of one expiry, giving total variance
+// w(k) = A + B (Rho (k - M) + sqrt((k - M)² + Sigma²)) at log-moneyness
+// k = ln(K/F)
+type SVIParams struct {
+	A     float64
+	B     float64 // >= 0
+	Rho   float64 // in (-1, 1)
+	M     float64
+	Sigma float64 // > 0
+}
+
+// SmileFit is a calibrated smile at one expiry. Exactly one of SABR and SVI
+// is set.
+type SmileFit struct {
+	Maturity  time.Time
+	Forward   float64 // outright forward of the fitted pair
+	SABR      *SABRParams
+	SVI       *SVIParams
+	Residuals []float64 // model minus market vol at each market point, by strike
+	RMSE      float64   // root mean square of Residuals
+	Inverted  bool      // fitted on the opposite pair; strikes are read as 1/K
+}
+
+// Vol returns the fitted volatility at a strike, for time t in years to the
+// fit's maturity
+func (f SmileFit) Vol(strike, t float64) float64 {
+	if f.Inverted {
+		strike = 1 / strike
+	}
+	if f.SABR != nil {
+		return f.SABR.vol(f.Forward, strike, t)
+	}
+	return f.SVI.vol(f.Forward, strike, t)
+}
+
+func (f SmileFit) validate(model string) error {
+	if f.Forward <= 0 || math.IsNaN(f.Forward) || math.IsInf(f.Forward, 0) {
+		return fmt.Errorf("forward %f must be positive", f.Forward)
+	}
+	switch model {
+	case VolInterpSABR:
+		if f.SABR == nil || f.SVI != nil {
+			return fmt.Errorf("expected SABR parameters")
+		}
+		p := f.SABR
+		if p.Alpha <= 0 || p.Beta < 0 || p.Beta > 1 || p.Rho <= -1 || p.Rho >= 1 || p.Nu < 0 {
+			return fmt.Errorf("invalid SABR parameters %+v", *p)
+		}
+	case VolInterpSVI:
+		if f.SVI == nil || f.SABR != nil {
+			return fmt.Errorf("expected SVI parameters")
+		}
+		p := f.SVI
+		if p.B < 0 || p.Rho <= -1 || p.Rho >= 1 || p.Sigma <= 0 {
+			return fmt.Errorf("invalid SVI parameters %+v", *p)
+		}
+		if p.A+p.B*p.Sigma*math.Sqrt(1-p.Rho*p.Rho) < 0 {
+			return fmt.Errorf("SVI parameters %+v give negative total variance", *p)
+		}
+	}
+	return nil
+}
+
+// vol is Hagan's lognormal implied vol expansion
+func (p SABRParams) vol(forward, strike, t float64) float64 {
+	oneMinusBeta := 1 - p.Beta
+	logFK := math.Log(forward / strike)
+	fkBeta := math.Pow(forward*strike, oneMinusBeta/2)
+
+	denominator := fkBeta * (1 + oneMinusBeta*oneMinusBeta/24*logFK*logFK +
+		math.Pow(oneMinusBeta, 4)/1920*math.Pow(logFK, 4))
+
+	z := p.Nu / p.Alpha * fkBeta * logFK
+	zOverX := 1.0
+	if math.Abs(z) > 1e-8 {
+		x := math.Log((math.Sqrt(1-2*p.Rho*z+z*z) + z - p.Rho) / (1 - p.Rho))
+		zOverX = z / x
+	}
+
+	correction := 1 + (oneMinusBeta*oneMinusBeta/24*p.Alpha*p.Alpha/(fkBeta*fkBeta)+
+		p.Rho*p.Beta*p.Nu*p.Alpha/(4*fkBeta)+
+		(2-3*p.Rho*p.Rho)/24*p.Nu*p.Nu)*t
+	return p.Alpha / denominator * zOverX * correction
+}
+
+// vol converts SVI total variance to a vol
+func (p SVIParams) vol(forward, strike, t float64) float64 {
+	k := math.Log(strike/forward) - p.M
+	w := p.A + p.B*(p.Rho*k+math.Sqrt(k*k+p.Sigma*p.Sigma))
+	return math.Sqrt(math.Max(w, 0) / t)
+}
+
+// CalibrateSABR fits SABR with a fixed beta to each expiry of a grid surface
+// and returns the surface with its Fits set and SABR interpolation. Each
+// expiry needs at least three strikes.
+func CalibrateSABR(surface VolSurface, mkt SmileMarket, beta float64) (VolSurface, error) {
+	if beta < 0 || beta > 1 {
+		return VolSurface{}, fmt.Errorf("vol surface %s: SABR beta %f must be between 0 and 1", surface.Pair, beta)
+	}
+	return calibrate(surface, mkt, VolInterpSABR, func(forward, t float64, strikes, vols []float64) (SmileFit, error) {
+		return fitSABR(forward, t, strikes, vols, beta)
+	})
+}
+
+// CalibrateSVI fits raw SVI to each expiry of a grid surface and returns the
+// surface with its Fits set and SVI interpolation. Each expiry needs at least
+// five strikes.
+func CalibrateSVI(surface VolSurface, mkt SmileMarket) (VolSurface, error) {
+	return calibrate(surface, mkt, VolInterpSVI, fitSVI)
+}
+
+// CalibrateVolSurface calibrates the stored grid of a pair to "SABR" or
+// "SVI", using the stored spot and curves for the forwards, and stores the
+// calibrated surface. beta is only used by SABR.
+func (m *Manager) CalibrateVolSurface(pair, model string, beta float64) error {
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return err
+	}
+	// Calibrate in the orientation the surface is stored in
+	m.mu.RLock()
+	surface, ok := m.volSurfaces[currencyPair.String()]
+	if !ok {
+		currencyPair = currencyPair.Invert()
+		surface, ok = m.volSurfaces[currencyPair.String()]
+	}
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("vol surface not found for pair %s", pair)
+	}
+
+	spot, err := m.GetSpotRate(currencyPair.String())
+	if err != nil {
+		return err
+	}
+	domestic, err := m.GetDiscountCurve(currencyPair.Quote.String())
+	if err != nil {
+		return err
+	}
+	foreign, err := m.GetDiscountCurve(currencyPair.Base.String())
+	if err != nil {
+		return err
+	}
+	mkt := SmileMarket{ReferenceDate: surface.ReferenceDate, Spot: spot.Rate, Domestic: domestic, Foreign: foreign}
+
+	switch model {
+	case VolInterpSABR:
+		surface, err = CalibrateSABR(surface, mkt, beta)
+	case VolInterpSVI:
+		surface, err = CalibrateSVI(surface, mkt)
+	default:
+		return fmt.Errorf("unknown smile model %q: expected SABR or SVI", model)
+	}
+	if err != nil {
+		return err
+	}
+	if err := m.checkArbitrage(surface); err != nil {
+		return err
+	}
+	surface.Timestamp = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.volSurfaces[surface.Pair] = surface
+
+	worst := 0.0
+	for _, fit := range surface.Fits {
+		worst = math.Max(worst, fit.RMSE)
+	}
+	m.logger.Info("calibrated vol surface",
+		zap.String("pair", surface.Pair),
+		zap.String("model", model),
+		zap.Int("expiries", len(surface.Fits)),
+		zap.Float64("max_rmse", worst),
+	)
+
+	return nil
+}
+
+type smileFitter func(forward, t float64, strikes, vols []float64) (SmileFit, error)
+
+func calibrate(surface VolSurface, mkt SmileMarket, model string, fit smileFitter) (VolSurface, error) {
+	if len(surface.Points) == 0 {
+		return VolSurface{}, fmt.Errorf("vol surface %s has no grid points to calibrate", surface.Pair)
+	}
+	// Check the market points as a plain grid
+	grid := surface
+	grid.Interpolation, grid.Fits = VolInterpLinear, nil
+	if err := grid.Validate(); err != nil {
+		return VolSurface{}, err
+	}
+	if mkt.Spot <= 0 {
+		return VolSurface{}, fmt.Errorf("vol surface %s: spot %f must be positive", surface.Pair, mkt.Spot)
+	}
+	mkt.ReferenceDate = surface.ReferenceDate
+
+	fits := make([]SmileFit, 0)
+	for _, slice := range surface.Slices() {
+		maturity := slice[0].Maturity
+		forward, _, err := mkt.forward(maturity)
+		if err != nil {
+			return VolSurface{}, fmt.Errorf("vol surface %s: %s: %w", surface.Pair, maturity.Format("2006-01-02"), err)
+		}
+
+		strikes := make([]float64, len(slice))
+		vols := make([]float64, len(slice))
+		for i, point := range slice {
+			strikes[i], vols[i] = point.Strike, point.Volatility
+		}
+
+		f, err := fit(forward, surface.VolTime(maturity), strikes, vols)
+		if err != nil {
+			return VolSurface{}, fmt.Errorf("vol surface %s: %s %s: %w", surface.Pair, model, maturity.Format("2006-01-02"), err)
+		}
+		f.Maturity = maturity
+		fits = append(fits, f)
+	}
+
+	surface.Interpolation = model
+	surface.Fits = fits
+	return surface, nil
+}
+
+// fitSABR fits alpha, rho and nu, mapped through exp, tanh and exp
+func fitSABR(forward, t float64, strikes, vols []float64, beta float64) (SmileFit, error) {
+	if len(strikes) < 3 {
+		return SmileFit{}, fmt.Errorf("%d strikes are too few for SABR: need 3", len(strikes))
+	}
+	params := func(x []float64) SABRParams {
+		return SABRParams{Alpha: math.Exp(x[0]), Beta: beta, Rho: math.Tanh(x[1]), Nu: math.Exp(x[2])}
+	}
+
+	// Start from the ATM level: σ_ATM ≈ α / F^(1-β)
+	atmVol := nearestVol(forward, strikes, vols)
+	x0 := []float64{math.Log(atmVol * math.Pow(forward, 1-beta)), 0, math.Log(0.5)}
+	x, err := levenbergMarquardt(func(x []float64) []float64 {
+		p := params(x)
+		return volResiduals(strikes, vols, func(k float64) float64 { return p.vol(forward, k, t) })
+	}, x0)
+	if err != nil {
+		return SmileFit{}, err
+	}
+
+	p := params(x)
+	fit := SmileFit{Forward: forward, SABR: &p}
+	fit.setResiduals(strikes, vols, t)
+	return fit, nil
+}
+
+// fitSVI fits raw SVI with b, σ > 0 and |ρ| < 1, and with a parameterised
+// through the minimum total variance a + bσ sqrt(1-ρ²) so it stays positive
+func fitSVI(forward, t float64, strikes, vols []float64) (SmileFit, error) {
+	if len(strikes) < 5 {
+		return SmileFit{}, fmt.Errorf("%d strikes are too few for SVI: need 5", len(strikes))
+	}
+	params := func(x []float64) SVIParams {
+		b, rho, sigma := math.Exp(x[1]), math.Tanh(x[2]), math.Exp(x[4])
+		return SVIParams{
+			A:     math.Exp(x[0]) - b*sigma*math.Sqrt(1-rho*rho),
+			B:     b,
+			Rho:   rho,
+			M:     x[3],
+			Sigma: sigma,
+		}
+	}
+
+	// Start symmetric around the forward with the ATM total variance
+	atmVariance := math.Pow(nearestVol(forward, strikes, vols), 2) * t
+	x0 := []float64{math.Log(atmVariance), math.Log(0.1 * math.Sqrt(t)), 0, 0, math.Log(0.1)}
+	x, err := levenbergMarquardt(func(x []float64) []float64 {
+		p := params(x)
+		return volResiduals(strikes, vols, func(k float64) float64 { return p.vol(forward, k, t) })
+	}, x0)
+	if err != nil {
+		return SmileFit{}, err
+	}
+
+	p := params(x)
+	fit := SmileFit{Forward: forward, SVI: &p}
+	fit.setResiduals(strikes, vols, t)
+	return fit, nil
+}
+
+func (f *SmileFit) setResiduals(strikes, vols []float64, t float64) {
+	f.Residuals = volResiduals(strikes, vols, func(k float64) float64 { return f.Vol(k, t) })
+	f.RMSE = math.Sqrt(sumSquares(f.Residuals) / float64(len(f.Residuals)))
+}
+
+func volResiduals(strikes, vols []float64, model func(strike float64) float64) []float64 {
+	residuals := make([]float64, len(strikes))
+	for i, k := range strikes {
+		residuals[i] = model(k) - vols[i]
+	}
+	return residuals
+}
+
+// nearestVol returns the market vol at the strike closest to the forward
+func nearestVol(forward float64, strikes, vols []float64) float64 {
+	i := sort.SearchFloat64s(strikes, forward)
+	switch {
+	case i == len(strikes):
+		i--
+	case i > 0 && forward-strikes[i-1] < strikes[i]-forward:
+		i--
+	}
+	return vols[i]
+}
@@ -0,0 +1,159 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFitSABRRecoversParameters(t *testing.T) {
+	forward, tau := 1.1, 1.0
+	strikes := []float64{0.85, 0.9, 0.95, 1.0, 1.05, 1.1, 1.15, 1.2, 1.25, 1.3, 1.4}
+
+	for _, want := range []SABRParams{
+		{Alpha: 0.10, Beta: 1, Rho: -0.3, Nu: 0.6},
+		{Alpha: 0.11, Beta: 0.5, Rho: 0.25, Nu: 0.9},
+		{Alpha: 0.12, Beta: 0, Rho: -0.1, Nu: 0.4},
+	} {
+		vols := make([]float64, len(strikes))
+		for i, k := range strikes {
+			vols[i] = want.vol(forward, k, tau)
+		}
+
+		fit, err := fitSABR(forward, tau, strikes, vols, want.Beta)
+		if err != nil {
+			t.Fatalf("beta %g: fitSABR: %v", want.Beta, err)
+		}
+		got := *fit.SABR
+		if math.Abs(got.Alpha-want.Alpha) > 1e-6 || math.Abs(got.Rho-want.Rho) > 1e-5 || math.Abs(got.Nu-want.Nu) > 1e-5 {
+			t.Errorf("beta %g: fitted %+v, want %+v", want.Beta, got, want)
+		}
+		if got.Beta != want.Beta {
+			t.Errorf("beta %g: calibration moved beta to %g", want.Beta, got.Beta)
+		}
+		if fit.RMSE > 1e-8 {
+			t.Errorf("beta %g: RMSE %g on an exact SABR smile", want.Beta, fit.RMSE)
+		}
+	}
+}
+
+func TestFitSVIRecoversParameters(t *testing.T) {
+	forward, tau := 1.1, 0.5
+	var strikes []float64
+	for k := -0.3; k <= 0.3+1e-9; k += 0.05 {
+		strikes = append(strikes, forward*math.Exp(k))
+	}
+
+	for _, want := range []SVIParams{
+		{A: 0.002, B: 0.04, Rho: -0.4, M: 0.01, Sigma: 0.1},
+		{A: 0.004, B: 0.06, Rho: 0.2, M: -0.03, Sigma: 0.15},
+	} {
+		vols := make([]float64, len(strikes))
+		for i, k := range strikes {
+			vols[i] = want.vol(forward, k, tau)
+		}
+
+		fit, err := fitSVI(forward, tau, strikes, vols)
+		if err != nil {
+			t.Fatalf("fitSVI: %v", err)
+		}
+		got := *fit.SVI
+		for _, c := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"A", got.A, want.A}, {"B", got.B, want.B}, {"Rho", got.Rho, want.Rho},
+			{"M", got.M, want.M}, {"Sigma", got.Sigma, want.Sigma},
+		} {
+			if math.Abs(c.got-c.want) > 1e-4 {
+				t.Errorf("%+v: fitted %s = %g, want %g", want, c.name, c.got, c.want)
+			}
+		}
+		if fit.RMSE > 1e-8 {
+			t.Errorf("%+v: RMSE %g on an exact SVI smile", want, fit.RMSE)
+		}
+	}
+}
+
+func TestCalibrateTooFewStrikes(t *testing.T) {
+	if _, err := fitSABR(1, 1, []float64{0.9, 1.1}, []float64{0.1, 0.1}, 1); err == nil {
+		t.Error("SABR should need three strikes")
+	}
+	if _, err := fitSVI(1, 1, []float64{0.8, 0.9, 1.1, 1.2}, []float64{0.1, 0.1, 0.1, 0.1}); err == nil {
+		t.Error("SVI should need five strikes")
+	}
+}
+
+func TestCalibrateSABRSurfaceReprices(t *testing.T) {
+	mkt := testSmileMarket()
+	want := SABRParams{Alpha: 0.1, Beta: 1, Rho: -0.2, Nu: 0.5}
+	surface := VolSurface{Pair: "USD/JPY", ReferenceDate: mkt.ReferenceDate}
+	for _, maturity := range []time.Time{date(2026, time.July, 2), date(2027, time.January, 4)} {
+		forward, _, err := mkt.forward(maturity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range []float64{0.85, 0.92, 1, 1.08, 1.15} {
+			strike := forward * m
+			surface.Points = append(surface.Points, VolPoint{
+				Strike:     strike,
+				Maturity:   maturity,
+				Volatility: want.vol(forward, strike, surface.VolTime(maturity)),
+			})
+		}
+	}
+
+	calibrated, err := CalibrateSABR(surface, mkt, want.Beta)
+	if err != nil {
+		t.Fatalf("CalibrateSABR: %v", err)
+	}
+	if calibrated.Interpolation != VolInterpSABR || len(calibrated.Fits) != 2 {
+		t.Fatalf("got %s interpolation with %d fits", calibrated.Interpolation, len(calibrated.Fits))
+	}
+	for _, p := range surface.Points {
+		got, err := calibrated.Vol(p.Strike, p.Maturity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-p.Volatility) > 1e-8 {
+			t.Errorf("vol at %g, %s = %g, want %g", p.Strike, p.Maturity.Format("2006-01-02"), got, p.Volatility)
+		}
+	}
+}
+
+func TestLevenbergMarquardtConverges(t *testing.T) {
+	// Rosenbrock as least squares: r = (10 (y - x²), 1 - x), minimum at (1, 1)
+	rosenbrock := func(x []float64) []float64 {
+		return []float64{10 * (x[1] - x[0]*x[0]), 1 - x[0]}
+	}
+	x, err := levenbergMarquardt(rosenbrock, []float64{-1.2, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(x[0]-1) > 1e-6 || math.Abs(x[1]-1) > 1e-6 {
+		t.Errorf("minimum at %v, want [1 1]", x)
+	}
+
+	// An overdetermined linear fit converges to the least-squares line
+	xs := []float64{0, 1, 2, 3}
+	ys := []float64{1, 3.1, 4.9, 7.2}
+	line := func(p []float64) []float64 {
+		r := make([]float64, len(xs))
+		for i := range xs {
+			r[i] = p[0] + p[1]*xs[i] - ys[i]
+		}
+		return r
+	}
+	p, err := levenbergMarquardt(line, []float64{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Closed form: slope 2.04, intercept 0.99
+	if math.Abs(p[0]-0.99) > 1e-6 || math.Abs(p[1]-2.04) > 1e-6 {
+		t.Errorf("line fit %v, want [0.99 2.04]", p)
+	}
+
+	if _, err := levenbergMarquardt(func([]float64) []float64 { return []float64{math.NaN()} }, []float64{0}); err == nil {
+		t.Error("non-finite initial residuals should fail")
+	}
+}
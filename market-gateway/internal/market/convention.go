@@ -0,0 +1,236 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Compounding is the frequency at which a quoted rate compounds
+type Compounding int
+
+const (
+	// CompoundContinuous discounts with exp(-r t)
+	CompoundContinuous Compounding = iota
+	// CompoundAnnual discounts with (1 + r)^-t
+	CompoundAnnual
+	// CompoundSemiannual discounts with (1 + r/2)^-2t
+	CompoundSemiannual
+	// CompoundQuarterly discounts with (1 + r/4)^-4t
+	CompoundQuarterly
+	// CompoundSimple discounts with 1 / (1 + r t), as money-market deposits do
+	CompoundSimple
+)
+
+func (c Compounding) String() string {
+	switch c {
+	case CompoundContinuous:
+		return "continuous"
+	case CompoundAnnual:
+		return "annual"
+	case CompoundSemiannual:
+		return "semiannual"
+	case CompoundQuarterly:
+		return "quarterly"
+	case CompoundSimple:
+		return "simple"
+	}
+	return ""
+}
+
+// ParseCompounding parses "continuous", "annual", "semiannual", "quarterly"
+// or "simple"
+func ParseCompounding(s string) (Compounding, error) {
+	for _, c := range []Compounding{CompoundContinuous, CompoundAnnual, CompoundSemiannual, CompoundQuarterly, CompoundSimple} {
+		if c.String() == s {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown compounding: %s", s)
+}
+
+// periods returns the compounding periods per year, 0 for continuous and simple
+func (c Compounding) periods() float64 {
+	switch c {
+	case CompoundAnnual:
+		return 1
+	case CompoundSemiannual:
+		return 2
+	case CompoundQuarterly:
+		return 4
+	}
+	return 0
+}
+
+// DiscountFactor returns the discount factor of a rate over t years
+func (c Compounding) DiscountFactor(rate, t float64) float64 {
+	switch c {
+	case CompoundContinuous:
+		return math.Exp(-rate * t)
+	case CompoundSimple:
+		return 1 / (1 + rate*t)
+	}
+	n := c.periods()
+	return math.Pow(1+rate/n, -n*t)
+}
+
+// Rate returns the rate that discounts to df over t years. t must be positive.
+func (c Compounding) Rate(df, t float64) float64 {
+	switch c {
+	case CompoundContinuous:
+		return -math.Log(df) / t
+	case CompoundSimple:
+		return (1/df - 1) / t
+	}
+	n := c.periods()
+	return n * (math.Pow(df, -1/(n*t)) - 1)
+}
+
+// DayCount is the convention turning a pair of dates into a year fraction
+type DayCount int
+
+const (
+	// DayCountAct365F divides actual days by 365
+	DayCountAct365F DayCount = iota
+	// DayCountAct360 divides actual days by 360 (USD and EUR money markets)
+	DayCountAct360
+	// DayCount30360 counts 30-day months and 360-day years (ISDA bond basis)
+	DayCount30360
+	// DayCountActActISDA divides the days falling in each calendar year by
+	// that year's length, 365 or 366
+	DayCountActActISDA
+)
+
+func (d DayCount) String() string {
+	switch d {
+	case DayCountAct365F:
+		return "ACT/365F"
+	case DayCountAct360:
+		return "ACT/360"
+	case DayCount30360:
+		return "30/360"
+	case DayCountActActISDA:
+		return "ACT/ACT ISDA"
+	}
+	return ""
+}
+
+// ParseDayCount parses "ACT/365F", "ACT/360", "30/360" or "ACT/ACT ISDA"
+func ParseDayCount(s string) (DayCount, error) {
+	for _, d := range []DayCount{DayCountAct365F, DayCountAct360, DayCount30360, DayCountActActISDA} {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day count: %s", s)
+}
+
+// YearFraction returns the time from start to end in years. Only the
+// calendar dates count; it is negative when end is before start.
+func (d DayCount) YearFraction(start, end time.Time) float64 {
+	start, end = calendarDate(start), calendarDate(end)
+	if end.Before(start) {
+		return -d.YearFraction(end, start)
+	}
+
+	switch d {
+	case DayCountAct360:
+		return daysBetween(start, end) / 360
+	case DayCount30360:
+		d1, d2 := start.Day(), end.Day()
+		if d1 == 31 {
+			d1 = 30
+		}
+		if d2 == 31 && d1 == 30 {
+			d2 = 30
+		}
+		days := 360*(end.Year()-start.Year()) + 30*(int(end.Month())-int(start.Month())) + d2 - d1
+		return float64(days) / 360
+	case DayCountActActISDA:
+		fraction := 0.0
+		for year := start.Year(); year <= end.Year(); year++ {
+			from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			to := from.AddDate(1, 0, 0)
+			if start.After(from) {
+				from = start
+			}
+			if end.Before(to) {
+				to = end
+			}
+			fraction += daysBetween(from, to) / daysInYear(year)
+		}
+		return fraction
+	default:
+		return daysBetween(start, end) / 365
+	}
+}
+
+// calendarDate drops the time of day and location of t
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(start, end time.Time) float64 {
+	return math.Round(end.Sub(start).Hours() / 24)
+}
+
+func daysInYear(year int) float64 {
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		return 366
+	}
+	return 365
+}
+
+// RateConvention is how a rate is quoted: its compounding and the day count
+// measuring the accrual period
+type RateConvention struct {
+	Compounding Compounding
+	DayCount    DayCount
+}
+
+func (c RateConvention) String() string {
+	return fmt.Sprintf("%s %s", c.Compounding, c.DayCount)
+}
+
+// Validate checks that the compounding and day count are known
+func (c RateConvention) Validate() error {
+	if c.Compounding.String() == "" {
+		return fmt.Errorf("invalid compounding %d", int(c.Compounding))
+	}
+	if c.DayCount.String() == "" {
+		return fmt.Errorf("invalid day count %d", int(c.DayCount))
+	}
+	return nil
+}
+
+// ParseRateConvention parses a compounding and a day count
+func ParseRateConvention(compounding, dayCount string) (RateConvention, error) {
+	c, err := ParseCompounding(compounding)
+	if err != nil {
+		return RateConvention{}, err
+	}
+	d, err := ParseDayCount(dayCount)
+	if err != nil {
+		return RateConvention{}, err
+	}
+	return RateConvention{Compounding: c, DayCount: d}, nil
+}
+
+// ConvertRate re-quotes a rate for the period from start to end in another
+// convention, keeping the discount factor over the period unchanged. For
+// example a 5% ACT/360 simple deposit rate is about 5.04% ACT/365F
+// continuous over three months.
+func ConvertRate(rate float64, start, end time.Time, from, to RateConvention) (float64, error) {
+	if err := from.Validate(); err != nil {
+		return 0, err
+	}
+	if err := to.Validate(); err != nil {
+		return 0, err
+	}
+	tFrom := from.DayCount.YearFraction(start, end)
+	tTo := to.DayCount.YearFraction(start, end)
+	if tFrom <= 0 || tTo <= 0 {
+		return 0, fmt.Errorf("invalid period %s to %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+	return to.Compounding.Rate(from.Compounding.DiscountFactor(rate, tFrom), tTo), nil
+}
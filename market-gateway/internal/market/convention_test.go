@@ -0,0 +1,96 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestConvertRateDepositToContinuous(t *testing.T) {
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+	from, err := ParseRateConvention("simple", "ACT/360")
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ParseRateConvention("continuous", "ACT/365F")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rate, err := ConvertRate(0.05, start, end, from, to)
+	if err != nil {
+		t.Fatalf("ConvertRate: %v", err)
+	}
+	if math.Abs(rate-0.0504) > 5e-5 {
+		t.Fatalf("ConvertRate = %.6f, want about 0.0504", rate)
+	}
+
+	back, err := ConvertRate(rate, start, end, to, from)
+	if err != nil {
+		t.Fatalf("ConvertRate: %v", err)
+	}
+	if math.Abs(back-0.05) > 1e-12 {
+		t.Fatalf("round trip = %.12f, want 0.05", back)
+	}
+}
+
+func TestCompoundingRoundTrips(t *testing.T) {
+	for _, c := range []Compounding{CompoundContinuous, CompoundAnnual, CompoundSemiannual, CompoundQuarterly, CompoundSimple} {
+		for _, tt := range []float64{0.25, 1, 7.5} {
+			df := c.DiscountFactor(0.04, tt)
+			if rate := c.Rate(df, tt); math.Abs(rate-0.04) > 1e-12 {
+				t.Errorf("%s: Rate(DiscountFactor(0.04, %v)) = %.12f", c, tt, rate)
+			}
+		}
+	}
+}
+
+func TestDayCountYearFractions(t *testing.T) {
+	tests := []struct {
+		dayCount   DayCount
+		start, end time.Time
+		want       float64
+	}{
+		{DayCountAct360, date(2026, 1, 15), date(2026, 4, 15), 90.0 / 360},
+		{DayCountAct365F, date(2024, 1, 1), date(2025, 1, 1), 366.0 / 365},
+		{DayCount30360, date(2026, 1, 31), date(2026, 3, 31), 60.0 / 360},
+		{DayCountActActISDA, date(2023, 7, 1), date(2024, 7, 1), 184.0/365 + 182.0/366},
+	}
+	for _, tt := range tests {
+		if got := tt.dayCount.YearFraction(tt.start, tt.end); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: YearFraction = %.12f, want %.12f", tt.dayCount, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateDiscountCurveConventions(t *testing.T) {
+	m := NewManager(zap.NewNop())
+
+	if err := m.UpdateDiscountCurve("USD", 0.05, "continuous"); err != nil {
+		t.Fatal(err)
+	}
+	curve, err := m.GetDiscountCurve("USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if curve.DayCount != DayCountAct365F {
+		t.Fatalf("default day count = %s, want ACT/365F", curve.DayCount)
+	}
+
+	if err := m.UpdateDiscountCurveWithDayCount("EUR", 0.03, "simple", "ACT/360"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UpdateDiscountCurve("GBP", 0.04, "monthly"); err == nil {
+		t.Fatal("unknown compounding was accepted")
+	}
+	if err := m.UpdateDiscountCurveWithDayCount("GBP", 0.04, "simple", "ACT/364"); err == nil {
+		t.Fatal("unknown day count was accepted")
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,522 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// psdTolerance is how negative an eigenvalue may be and still count as zero
+const psdTolerance = 1e-10
+
+// CorrelationKey returns the snapshot key of the correlation between the log
+// returns of two pairs, e.g. "EUR/USD/GBP/USD". Both pairs are put in market
+// convention and ordered, so the key does not depend on how the pairs are
+// given. The returned sign is -1 when exactly one pair was inverted: the
+// correlation stored under the key must be multiplied by it.
+func CorrelationKey(a, b models.CurrencyPair) (string, float64) {
+	sign := 1.0
+	if !a.IsMarketConvention() {
+		a, sign = a.Invert(), -sign
+	}
+	if !b.IsMarketConvention() {
+		b, sign = b.Invert(), -sign
+	}
+	if b.String() < a.String() {
+		a, b = b, a
+	}
+	return a.String() + "/" + b.String(), sign
+}
+
+// ParseCorrelationKey splits a key such as "EUR/USD/GBP/USD" into its pairs
+func ParseCorrelationKey(key string) (models.CurrencyPair, models.CurrencyPair, error) {
+	codes := strings.Split(key, "/")
+	if len(codes) != 4 {
+		return models.CurrencyPair{}, models.CurrencyPair{}, fmt.Errorf("invalid correlation key %q: expected CCY/CCY/CCY/CCY", key)
+	}
+	a, err := models.ParseCurrencyPair(codes[0] + "/" + codes[1])
+	if err != nil {
+		return models.CurrencyPair{}, models.CurrencyPair{}, fmt.Errorf("invalid correlation key %q: %w", key, err)
+	}
+	b, err := models.ParseCurrencyPair(codes[2] + "/" + codes[3])
+	if err != nil {
+		return models.CurrencyPair{}, models.CurrencyPair{}, fmt.Errorf("invalid correlation key %q: %w", key, err)
+	}
+	return a, b, nil
+}
+
+// CorrelationMatrix is the full correlation matrix of a set of pairs, all in
+// market convention
+type CorrelationMatrix struct {
+	Pairs  []models.CurrencyPair
+	Values [][]float64 // symmetric, unit diagonal
+}
+
+// NewCorrelationMatrix builds the matrix of every pair named in a map of
+// correlations keyed as by CorrelationKey. Pairs without a correlation
+// between them are taken as uncorrelated.
+func NewCorrelationMatrix(correlations map[string]float64) (CorrelationMatrix, error) {
+	index := make(map[string]int)
+	var pairs []models.CurrencyPair
+	type entry struct {
+		a, b  models.CurrencyPair
+		value float64
+	}
+	entries := make([]entry, 0, len(correlations))
+	for key, value := range correlations {
+		a, b, err := ParseCorrelationKey(key)
+		if err != nil {
+			return CorrelationMatrix{}, err
+		}
+		if err := validateCorrelation(a, b, value); err != nil {
+			return CorrelationMatrix{}, err
+		}
+		// Keys may come from elsewhere in any orientation
+		_, sign := CorrelationKey(a, b)
+		a, b = a.MarketConvention(), b.MarketConvention()
+		entries = append(entries, entry{a: a, b: b, value: sign * value})
+		for _, p := range []models.CurrencyPair{a, b} {
+			if _, ok := index[p.String()]; !ok {
+				index[p.String()] = -1
+				pairs = append(pairs, p)
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].String() < pairs[j].String() })
+	for i, p := range pairs {
+		index[p.String()] = i
+	}
+
+	m := CorrelationMatrix{Pairs: pairs, Values: identity(len(pairs))}
+	for _, e := range entries {
+		i, j := index[e.a.String()], index[e.b.String()]
+		m.Values[i][j], m.Values[j][i] = e.value, e.value
+	}
+	return m, nil
+}
+
+// Map returns the off-diagonal correlations keyed as by CorrelationKey,
+// including the zero ones
+func (m CorrelationMatrix) Map() map[string]float64 {
+	correlations := make(map[string]float64)
+	for i := range m.Pairs {
+		for j := i + 1; j < len(m.Pairs); j++ {
+			key, sign := CorrelationKey(m.Pairs[i], m.Pairs[j])
+			correlations[key] = sign * m.Values[i][j]
+		}
+	}
+	return correlations
+}
+
+// MinEigenvalue returns the smallest eigenvalue of the matrix
+func (m CorrelationMatrix) MinEigenvalue() float64 {
+	if len(m.Pairs) == 0 {
+		return 1
+	}
+	eigenvalues, _ := symmetricEigen(m.Values)
+	return eigenvalues[0]
+}
+
+// IsPSD reports whether the matrix is positive semi-definite, as every
+// correlation matrix must be
+func (m CorrelationMatrix) IsPSD() bool {
+	return m.MinEigenvalue() >= -psdTolerance
+}
+
+// NearestPSD returns the nearest correlation matrix in the Frobenius norm,
+// by Higham's alternating projections with Dykstra's correction
+func (m CorrelationMatrix) NearestPSD() CorrelationMatrix {
+	n := len(m.Pairs)
+	y := copyMatrix(m.Values)
+	correction := make([][]float64, n)
+	for i := range correction {
+		correction[i] = make([]float64, n)
+	}
+
+	for iteration := 0; iteration < 1000; iteration++ {
+		// Project onto the PSD cone, undoing the previous correction first
+		r := copyMatrix(y)
+		for i := range r {
+			for j := range r[i] {
+				r[i][j] -= correction[i][j]
+			}
+		}
+		x := projectPSD(r)
+		for i := range correction {
+			for j := range correction[i] {
+				correction[i][j] = x[i][j] - r[i][j]
+			}
+		}
+
+		// Project onto unit-diagonal matrices
+		previous := y
+		y = copyMatrix(x)
+		for i := range y {
+			y[i][i] = 1
+		}
+
+		change := 0.0
+		for i := range y {
+			for j := range y[i] {
+				change = math.Max(change, math.Abs(y[i][j]-previous[i][j]))
+			}
+		}
+		if change < 1e-12 && (CorrelationMatrix{Pairs: m.Pairs, Values: y}).IsPSD() {
+			break
+		}
+	}
+
+	// Symmetrise and clip the rounding left by the projections
+	for i := range y {
+		for j := i + 1; j < n; j++ {
+			v := math.Max(-1, math.Min(1, (y[i][j]+y[j][i])/2))
+			y[i][j], y[j][i] = v, v
+		}
+	}
+	return CorrelationMatrix{Pairs: append([]models.CurrencyPair(nil), m.Pairs...), Values: y}
+}
+
+// ImpliedCorrelation returns the correlation between two pairs sharing a
+// currency implied by their vols and the vol of the cross they form. With
+// X/Z, Y/Z and X/Y = (X/Z)/(Y/Z):
+//
+//	σ²(X/Y) = σ²(X/Z) + σ²(Y/Z) - 2ρ σ(X/Z) σ(Y/Z)
+func ImpliedCorrelation(a, b models.CurrencyPair, volA, volB, volCross float64) (float64, error) {
+	common, ok := commonCurrency(a, b)
+	if !ok {
+		return 0, fmt.Errorf("pairs %s and %s do not share exactly one currency", a, b)
+	}
+	if volA <= 0 || volB <= 0 || volCross <= 0 {
+		return 0, fmt.Errorf("vols must be positive: %s %f, %s %f, cross %f", a, volA, b, volB, volCross)
+	}
+
+	// Orient both pairs against the common currency, X/Z and Y/Z
+	sign := 1.0
+	if a.Base == common {
+		sign = -sign
+	}
+	if b.Base == common {
+		sign = -sign
+	}
+
+	rho := (volA*volA + volB*volB - volCross*volCross) / (2 * volA * volB)
+	if rho < -1-1e-12 || rho > 1+1e-12 {
+		return 0, fmt.Errorf("vols %f, %f and cross %f break the triangle inequality: implied correlation %f", volA, volB, volCross, rho)
+	}
+	return sign * math.Max(-1, math.Min(1, rho)), nil
+}
+
+// CrossPair returns the pair formed by the currencies two pairs do not share,
+// in market convention
+func CrossPair(a, b models.CurrencyPair) (models.CurrencyPair, error) {
+	common, ok := commonCurrency(a, b)
+	if !ok {
+		return models.CurrencyPair{}, fmt.Errorf("pairs %s and %s do not share exactly one currency", a, b)
+	}
+	return models.MarketPair(otherCurrency(a, common), otherCurrency(b, common)), nil
+}
+
+// UpdateCorrelation sets the correlation between two pairs, in either
+// orientation. The update is rejected if it leaves the full matrix, with
+// unset correlations taken as zero, no longer positive semi-definite.
+func (m *Manager) UpdateCorrelation(pairA, pairB string, rho float64) error {
+	a, err := models.ParseCurrencyPair(pairA)
+	if err != nil {
+		return err
+	}
+	b, err := models.ParseCurrencyPair(pairB)
+	if err != nil {
+		return err
+	}
+	if err := validateCorrelation(a, b, rho); err != nil {
+		return err
+	}
+	key, sign := CorrelationKey(a, b)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	correlations := make(map[string]float64, len(m.correlations)+1)
+	for k, v := range m.correlations {
+		correlations[k] = v
+	}
+	correlations[key] = sign * rho
+
+	matrix, err := NewCorrelationMatrix(correlations)
+	if err != nil {
+		return err
+	}
+	if minEigen := matrix.MinEigenvalue(); minEigen < -psdTolerance {
+		return fmt.Errorf("correlation %s = %f makes the correlation matrix indefinite (min eigenvalue %g)", key, sign*rho, minEigen)
+	}
+
+	m.correlations = correlations
+
+	m.logger.Info("updated correlation",
+		zap.String("key", key),
+		zap.Float64("correlation", sign*rho),
+	)
+
+	return nil
+}
+
+// UpdateCorrelations replaces every correlation, keyed as by CorrelationKey
+// in any orientation. An indefinite matrix is rejected, or replaced by the
+// nearest correlation matrix when repair is set; all of the repaired
+// matrix's correlations are then stored, including those it moved away from
+// zero.
+func (m *Manager) UpdateCorrelations(correlations map[string]float64, repair bool) error {
+	matrix, err := NewCorrelationMatrix(correlations)
+	if err != nil {
+		return err
+	}
+
+	adjustment := 0.0
+	if minEigen := matrix.MinEigenvalue(); minEigen < -psdTolerance {
+		if !repair {
+			return fmt.Errorf("correlation matrix is indefinite (min eigenvalue %g)", minEigen)
+		}
+		repaired := matrix.NearestPSD()
+		for i := range matrix.Values {
+			for j := range matrix.Values[i] {
+				adjustment = math.Max(adjustment, math.Abs(repaired.Values[i][j]-matrix.Values[i][j]))
+			}
+		}
+		matrix = repaired
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// A repaired matrix is only PSD as a whole, so every correlation it
+	// fills in is stored; otherwise only the given ones are, with unset
+	// correlations still read as zero
+	full := matrix.Map()
+	stored := full
+	if adjustment == 0 {
+		stored = make(map[string]float64, len(correlations))
+		for key := range correlations {
+			a, b, _ := ParseCorrelationKey(key)
+			canonical, _ := CorrelationKey(a, b)
+			stored[canonical] = full[canonical]
+		}
+	}
+	m.correlations = stored
+
+	m.logger.Info("updated correlations",
+		zap.Int("correlations", len(stored)),
+		zap.Int("pairs", len(matrix.Pairs)),
+		zap.Float64("repair_adjustment", adjustment),
+	)
+
+	return nil
+}
+
+// GetCorrelation returns the correlation between two pairs in the
+// orientations given. A pair is perfectly correlated with itself and
+// anti-correlated with its inverse; unset correlations are an error.
+func (m *Manager) GetCorrelation(pairA, pairB string) (float64, error) {
+	a, err := models.ParseCurrencyPair(pairA)
+	if err != nil {
+		return 0, err
+	}
+	b, err := models.ParseCurrencyPair(pairB)
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case a:
+		return 1, nil
+	case a.Invert():
+		return -1, nil
+	}
+
+	key, sign := CorrelationKey(a, b)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	rho, ok := m.correlations[key]
+	if !ok {
+		return 0, fmt.Errorf("correlation not found for %s", key)
+	}
+	return sign * rho, nil
+}
+
+// GetCorrelationMatrix returns the full matrix of the stored correlations
+func (m *Manager) GetCorrelationMatrix() (CorrelationMatrix, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return NewCorrelationMatrix(m.correlations)
+}
+
+// ImpliedCorrelation returns the correlation between two pairs sharing a
+// currency implied by the stored vols of the triangle they form, read at
+// each pair's spot for the given maturity
+func (m *Manager) ImpliedCorrelation(pairA, pairB string, maturity time.Time) (float64, error) {
+	a, err := models.ParseCurrencyPair(pairA)
+	if err != nil {
+		return 0, err
+	}
+	b, err := models.ParseCurrencyPair(pairB)
+	if err != nil {
+		return 0, err
+	}
+	cross, err := CrossPair(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	vols := make([]float64, 3)
+	for i, pair := range []models.CurrencyPair{a, b, cross} {
+		surface, err := m.GetVolSurface(pair.String())
+		if err != nil {
+			return 0, err
+		}
+		spot, err := m.GetSpotRate(pair.String())
+		if err != nil {
+			return 0, err
+		}
+		if vols[i], err = surface.Vol(spot.Rate, maturity); err != nil {
+			return 0, err
+		}
+	}
+	return ImpliedCorrelation(a, b, vols[0], vols[1], vols[2])
+}
+
+func validateCorrelation(a, b models.CurrencyPair, rho float64) error {
+	if a == b || a == b.Invert() {
+		return fmt.Errorf("correlation of %s with %s is fixed", a, b)
+	}
+	if math.IsNaN(rho) || rho < -1 || rho > 1 {
+		return fmt.Errorf("invalid correlation %f between %s and %s: must be between -1 and 1", rho, a, b)
+	}
+	return nil
+}
+
+// commonCurrency returns the one currency two distinct pairs share
+func commonCurrency(a, b models.CurrencyPair) (models.Currency, bool) {
+	if a == b || a == b.Invert() {
+		return 0, false
+	}
+	for _, c := range []models.Currency{a.Base, a.Quote} {
+		if c == b.Base || c == b.Quote {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+func otherCurrency(p models.CurrencyPair, c models.Currency) models.Currency {
+	if p.Base == c {
+		return p.Quote
+	}
+	return p.Base
+}
+
+// projectPSD zeroes the negative eigenvalues of a symmetric matrix
+func projectPSD(a [][]float64) [][]float64 {
+	eigenvalues, vectors := symmetricEigen(a)
+	n := len(a)
+	result := make([][]float64, n)
+	for i := range result {
+		result[i] = make([]float64, n)
+		for j := range result[i] {
+			for k, lambda := range eigenvalues {
+				if lambda > 0 {
+					result[i][j] += vectors[i][k] * lambda * vectors[j][k]
+				}
+			}
+		}
+	}
+	return result
+}
+
+// symmetricEigen diagonalises a symmetric matrix by cyclic Jacobi rotations,
+// returning ascending eigenvalues and the eigenvectors as columns
+func symmetricEigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	d := copyMatrix(a)
+	v := identity(n)
+
+	for sweep := 0; sweep < 100; sweep++ {
+		off := 0.0
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				off += d[i][j] * d[i][j]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if d[p][q] == 0 {
+					continue
+				}
+				theta := (d[q][q] - d[p][p]) / (2 * d[p][q])
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					dkp, dkq := d[k][p], d[k][q]
+					d[k][p], d[k][q] = c*dkp-s*dkq, s*dkp+c*dkq
+				}
+				for k := 0; k < n; k++ {
+					dpk, dqk := d[p][k], d[q][k]
+					d[p][k], d[q][k] = c*dpk-s*dqk, s*dpk+c*dqk
+				}
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return d[order[i]][order[i]] < d[order[j]][order[j]] })
+
+	eigenvalues := make([]float64, n)
+	vectors := make([][]float64, n)
+	for i := range vectors {
+		vectors[i] = make([]float64, n)
+	}
+	for k, col := range order {
+		eigenvalues[k] = d[col][col]
+		for i := 0; i < n; i++ {
+			vectors[i][k] = v[i][col]
+		}
+	}
+	return eigenvalues, vectors
+}
+
+func identity(n int) [][]float64 {
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = 1
+	}
+	return m
+}
+
+func copyMatrix(a [][]float64) [][]float64 {
+	c := make([][]float64, len(a))
+	for i := range a {
+		c[i] = append([]float64(nil), a[i]...)
+	}
+	return c
+}
@@ -0,0 +1,57 @@
+package market
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestUpdateCorrelationsRepairStoresPSDMatrix(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	// With EUR/USD and AUD/USD uncorrelated, both 0.9s cannot hold
+	correlations := map[string]float64{
+		"EUR/USD/GBP/USD": 0.9,
+		"AUD/USD/GBP/USD": 0.9,
+	}
+	if err := m.UpdateCorrelations(correlations, false); err == nil {
+		t.Fatal("indefinite matrix should be rejected without repair")
+	}
+	if err := m.UpdateCorrelations(correlations, true); err != nil {
+		t.Fatalf("UpdateCorrelations with repair: %v", err)
+	}
+
+	matrix, err := m.GetCorrelationMatrix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !matrix.IsPSD() {
+		t.Fatalf("stored matrix is indefinite: min eigenvalue %g", matrix.MinEigenvalue())
+	}
+	// The repair correlates EUR/USD with AUD/USD, and that is stored too
+	rho, err := m.GetCorrelation("EUR/USD", "AUD/USD")
+	if err != nil {
+		t.Fatalf("GetCorrelation: %v", err)
+	}
+	if rho <= 0 {
+		t.Errorf("repaired EUR/USD, AUD/USD correlation = %g, want positive", rho)
+	}
+
+	// Lowering a repaired correlation keeps the matrix PSD only if the
+	// repaired EUR/USD, AUD/USD correlation was kept
+	if err := m.UpdateCorrelation("EUR/USD", "GBP/USD", 0.7); err != nil {
+		t.Errorf("UpdateCorrelation after repair: %v", err)
+	}
+}
+
+func TestUpdateCorrelationsStoresOnlyGivenWithoutRepair(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateCorrelations(map[string]float64{"GBP/USD/EUR/USD": 0.5}, true); err != nil {
+		t.Fatal(err)
+	}
+	if rho, err := m.GetCorrelation("USD/GBP", "EUR/USD"); err != nil || rho != -0.5 {
+		t.Errorf("GetCorrelation(USD/GBP, EUR/USD) = %g, %v, want -0.5", rho, err)
+	}
+	if matrix, err := m.GetCorrelationMatrix(); err != nil || len(matrix.Map()) != 1 {
+		t.Errorf("stored %v, %v, want one correlation", matrix.Map(), err)
+	}
+}
@@ -0,0 +1,230 @@
+package market
+
+import (
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// CrossRouting selects how the manager derives rates for pairs it does not store
+type CrossRouting int
+
+const (
+	// CrossViaVehicle multiplies the two legs through the vehicle currency,
+	// e.g. EUR/JPY = EUR/USD * USD/JPY
+	CrossViaVehicle CrossRouting = iota
+	// CrossShortestPath chains the fewest stored pairs connecting the two
+	// currencies, in any orientation
+	CrossShortestPath
+	// CrossDisabled only returns stored pairs and their inverses
+	CrossDisabled
+)
+
+func (r CrossRouting) String() string {
+	switch r {
+	case CrossViaVehicle:
+		return "vehicle"
+	case CrossShortestPath:
+		return "shortest_path"
+	case CrossDisabled:
+		return "disabled"
+	}
+	return ""
+}
+
+// ParseCrossRouting parses "vehicle", "shortest_path" or "disabled"
+func ParseCrossRouting(s string) (CrossRouting, error) {
+	for _, r := range []CrossRouting{CrossViaVehicle, CrossShortestPath, CrossDisabled} {
+		if r.String() == s {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown cross routing: %s", s)
+}
+
+// SetVehicleCurrency sets the currency crosses are triangulated through (USD by default)
+func (m *Manager) SetVehicleCurrency(vehicle models.Currency) error {
+	if vehicle.String() == "" {
+		return fmt.Errorf("invalid vehicle currency %d", int(vehicle))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.vehicle = vehicle
+	m.logger.Info("set vehicle currency", zap.String("currency", vehicle.String()))
+	return nil
+}
+
+// SetCrossRouting sets how rates for pairs that are not stored are derived
+func (m *Manager) SetCrossRouting(routing CrossRouting) error {
+	if routing.String() == "" {
+		return fmt.Errorf("invalid cross routing %d", int(routing))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.crossRouting = routing
+	m.logger.Info("set cross routing", zap.String("routing", routing.String()))
+	return nil
+}
+
+// SnapshotFor returns a snapshot of the stored market data plus the spot
+// rates and vol surfaces the dependencies need, keyed exactly as the
+// dependencies name them: pairs stored only in the inverse orientation are
+// inverted, and crosses are derived from their legs. Pairs that are neither
+// stored nor derivable are left for Dependencies.Check to report.
+func (m *Manager) SnapshotFor(deps Dependencies) MarketSnapshot {
+	snapshot := m.GetSnapshot()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, pair := range deps.SpotRates {
+		if _, ok := snapshot.SpotRates[pair]; ok {
+			continue
+		}
+		currencyPair, err := models.ParseCurrencyPair(pair)
+		if err != nil {
+			continue
+		}
+		if spot, ok := m.storedRate(currencyPair); ok {
+			snapshot.SpotRates[pair] = spot
+		} else if spot, ok := m.crossRate(currencyPair); ok {
+			snapshot.SpotRates[pair] = spot
+		}
+	}
+	for _, dep := range deps.VolSurfaces {
+		if _, ok := snapshot.VolSurfaces[dep.Pair]; ok {
+			continue
+		}
+		currencyPair, err := models.ParseCurrencyPair(dep.Pair)
+		if err != nil {
+			continue
+		}
+		if surface, ok := m.volSurfaces[currencyPair.Invert().String()]; ok {
+			snapshot.VolSurfaces[dep.Pair] = invertVolSurface(surface, currencyPair)
+		}
+	}
+	return snapshot
+}
+
+// crossRate derives a rate for a pair that is not stored. The caller must
+// hold m.mu.
+func (m *Manager) crossRate(pair models.CurrencyPair) (SpotRate, bool) {
+	var path []models.Currency
+	switch m.crossRouting {
+	case CrossViaVehicle:
+		if pair.Base == m.vehicle || pair.Quote == m.vehicle {
+			return SpotRate{}, false
+		}
+		path = []models.Currency{pair.Base, m.vehicle, pair.Quote}
+	case CrossShortestPath:
+		path = m.shortestPath(pair.Base, pair.Quote)
+	}
+	if len(path) < 3 {
+		return SpotRate{}, false
+	}
+
+	legs := make([]SpotRate, 0, len(path)-1)
+	for i := 0; i+1 < len(path); i++ {
+		leg, ok := m.storedRate(models.NewCurrencyPair(path[i], path[i+1]))
+		if !ok {
+			return SpotRate{}, false
+		}
+		legs = append(legs, leg)
+	}
+
+	return chainRates(pair, legs), true
+}
+
+// storedRate returns a stored rate in the requested orientation
+func (m *Manager) storedRate(pair models.CurrencyPair) (SpotRate, bool) {
+	if spot, ok := m.spotRates[pair.String()]; ok {
+		return spot, true
+	}
+	if spot, ok := m.spotRates[pair.Invert().String()]; ok {
+		return invertSpotRate(spot, pair), true
+	}
+	return SpotRate{}, false
+}
+
+// shortestPath finds the fewest stored pairs linking two currencies by
+// breadth-first search, returning the currencies along the way
+func (m *Manager) shortestPath(from, to models.Currency) []models.Currency {
+	neighbours := make(map[models.Currency][]models.Currency)
+	for key := range m.spotRates {
+		p, err := models.ParseCurrencyPair(key)
+		if err != nil {
+			continue
+		}
+		neighbours[p.Base] = append(neighbours[p.Base], p.Quote)
+		neighbours[p.Quote] = append(neighbours[p.Quote], p.Base)
+	}
+	// Map iteration order is random; sort for a deterministic route
+	for _, adjacent := range neighbours {
+		sort.Slice(adjacent, func(i, j int) bool { return adjacent[i] < adjacent[j] })
+	}
+
+	previous := map[models.Currency]models.Currency{from: from}
+	queue := []models.Currency{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			break
+		}
+		for _, next := range neighbours[current] {
+			if _, seen := previous[next]; !seen {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if _, reached := previous[to]; !reached {
+		return nil
+	}
+	path := []models.Currency{to}
+	for c := to; c != from; c = previous[c] {
+		path = append([]models.Currency{previous[c]}, path...)
+	}
+	return path
+}
+
+// chainRates multiplies legs A/B, B/C, ... into A/Z. Bids multiply with bids
+// and asks with asks, since selling A for Z means selling through every leg.
+// The cross is only two-way if every leg is.
+func chainRates(pair models.CurrencyPair, legs []SpotRate) SpotRate {
+	cross := SpotRate{
+		Pair:      pair.String(),
+		Rate:      1,
+		Bid:       1,
+		Ask:       1,
+		Derived:   true,
+		Sources:   legs,
+		Timestamp: legs[0].Timestamp,
+	}
+
+	twoWay := true
+	for _, leg := range legs {
+		cross.Rate *= leg.Rate
+		if leg.IsTwoWay() {
+			cross.Bid *= leg.Bid
+			cross.Ask *= leg.Ask
+		} else {
+			twoWay = false
+		}
+		if leg.Timestamp.Before(cross.Timestamp) {
+			cross.Timestamp = leg.Timestamp
+		}
+	}
+	if !twoWay {
+		cross.Bid, cross.Ask = 0, 0
+	}
+	return cross
+}
@@ -0,0 +1,262 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+func TestSnapshotForAddsDerivedCrosses(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateSpotRate("EUR/USD", 1.1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UpdateSpotRate("USD/JPY", 150); err != nil {
+		t.Fatal(err)
+	}
+	for _, currency := range []string{"EUR", "USD", "JPY"} {
+		if err := m.UpdateDiscountCurve(currency, 0.02, "continuous"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.UpdateVolSurface("EUR/JPY", 0.1); err != nil {
+		t.Fatal(err)
+	}
+
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	deps, err := AnalyzeDependencies(models.NewCallOption(160, maturity, models.JPY, models.EUR))
+	if err != nil {
+		t.Fatalf("AnalyzeDependencies: %v", err)
+	}
+
+	if err := deps.Check(m.GetSnapshot()); err == nil {
+		t.Fatal("stored data alone should lack the EUR/JPY spot")
+	}
+	snapshot := m.SnapshotFor(deps)
+	if err := deps.Check(snapshot); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	cross := snapshot.SpotRates["EUR/JPY"]
+	if !cross.Derived || math.Abs(cross.Rate-165) > 1e-9 {
+		t.Fatalf("EUR/JPY = %+v, want derived rate 165", cross)
+	}
+	if _, ok := m.GetSnapshot().SpotRates["EUR/JPY"]; ok {
+		t.Fatal("SnapshotFor must not store the derived cross")
+	}
+}
+
+func TestSnapshotForKeysInversePairsAsDependencies(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateSpotQuote("USD/EUR", SpotQuote{Bid: 0.90, Ask: 0.91}); err != nil {
+		t.Fatal(err)
+	}
+	reference := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	maturity := time.Date(2027, 1, 4, 0, 0, 0, 0, time.UTC)
+	if err := m.UpdateVolGrid(VolSurface{
+		Pair:          "USD/EUR",
+		ReferenceDate: reference,
+		Points: []VolPoint{
+			{Strike: 0.85, Maturity: maturity, Volatility: 0.11},
+			{Strike: 0.95, Maturity: maturity, Volatility: 0.10},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, currency := range []string{"EUR", "USD"} {
+		if err := m.UpdateDiscountCurve(currency, 0.02, "continuous"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deps, err := AnalyzeDependencies(models.NewCallOption(1.1, maturity, models.USD, models.EUR))
+	if err != nil {
+		t.Fatalf("AnalyzeDependencies: %v", err)
+	}
+	snapshot := m.SnapshotFor(deps)
+	if err := deps.Check(snapshot); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	spot, ok := snapshot.SpotRates["EUR/USD"]
+	if !ok {
+		t.Fatal("snapshot has no EUR/USD spot")
+	}
+	if spot.Pair != "EUR/USD" || math.Abs(spot.Bid-1/0.91) > 1e-12 || math.Abs(spot.Ask-1/0.90) > 1e-12 {
+		t.Errorf("EUR/USD = %+v, want the inverted USD/EUR quote", spot)
+	}
+
+	surface, ok := snapshot.VolSurfaces["EUR/USD"]
+	if !ok {
+		t.Fatal("snapshot has no EUR/USD vol surface")
+	}
+	vol, err := surface.Vol(1/0.85, maturity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if surface.Pair != "EUR/USD" || math.Abs(vol-0.11) > 1e-12 {
+		t.Errorf("EUR/USD vol at 1/0.85 = %g on %s, want 0.11", vol, surface.Pair)
+	}
+}
+
+// crossManager stores two-way quotes for the given pairs
+func crossManager(t *testing.T, quotes map[string]SpotQuote) *Manager {
+	t.Helper()
+	m := NewManager(zap.NewNop())
+	for pair, quote := range quotes {
+		if err := m.UpdateSpotQuote(pair, quote); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestCrossBidAskThroughInvertedLeg(t *testing.T) {
+	eurusd := SpotQuote{Bid: 1.0999, Ask: 1.1001}
+	wantBid, wantAsk := 1.0999*149.99, 1.1001*150.01
+
+	for name, quotes := range map[string]map[string]SpotQuote{
+		"USD/JPY": {"EUR/USD": eurusd, "USD/JPY": {Bid: 149.99, Ask: 150.01}},
+		"JPY/USD": {"EUR/USD": eurusd, "JPY/USD": {Bid: 1 / 150.01, Ask: 1 / 149.99}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cross, err := crossManager(t, quotes).GetSpotRate("EUR/JPY")
+			if err != nil {
+				t.Fatalf("GetSpotRate: %v", err)
+			}
+			if math.Abs(cross.Bid-wantBid) > 1e-9 || math.Abs(cross.Ask-wantAsk) > 1e-9 {
+				t.Errorf("EUR/JPY = %.6f/%.6f, want %.6f/%.6f", cross.Bid, cross.Ask, wantBid, wantAsk)
+			}
+			if cross.Rate <= cross.Bid || cross.Rate >= cross.Ask {
+				t.Errorf("EUR/JPY mid %g is outside %g/%g", cross.Rate, cross.Bid, cross.Ask)
+			}
+
+			// The inverse cross swaps and inverts the sides
+			inverse, err := crossManager(t, quotes).GetSpotRate("JPY/EUR")
+			if err != nil {
+				t.Fatalf("GetSpotRate: %v", err)
+			}
+			if math.Abs(inverse.Bid-1/wantAsk) > 1e-12 || math.Abs(inverse.Ask-1/wantBid) > 1e-12 {
+				t.Errorf("JPY/EUR = %g/%g, want %g/%g", inverse.Bid, inverse.Ask, 1/wantAsk, 1/wantBid)
+			}
+		})
+	}
+}
+
+func TestCrossIsOneWayUnlessEveryLegIsTwoWay(t *testing.T) {
+	m := crossManager(t, map[string]SpotQuote{"EUR/USD": {Bid: 1.0999, Ask: 1.1001}})
+	if err := m.UpdateSpotRate("USD/JPY", 150); err != nil {
+		t.Fatal(err)
+	}
+	cross, err := m.GetSpotRate("EUR/JPY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cross.IsTwoWay() {
+		t.Errorf("EUR/JPY = %g/%g, want a one-way rate", cross.Bid, cross.Ask)
+	}
+}
+
+func TestCrossMetadata(t *testing.T) {
+	m := crossManager(t, map[string]SpotQuote{"JPY/USD": {Bid: 1 / 150.01, Ask: 1 / 149.99}})
+	time.Sleep(time.Millisecond)
+	if err := m.UpdateSpotRate("EUR/USD", 1.1); err != nil {
+		t.Fatal(err)
+	}
+	jpy, err := m.GetSpotRate("JPY/USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cross, err := m.GetSpotRate("EUR/JPY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cross.Derived || cross.Pair != "EUR/JPY" {
+		t.Errorf("cross = %+v, want a derived EUR/JPY", cross)
+	}
+	// Legs are oriented along the path EUR -> USD -> JPY
+	if len(cross.Sources) != 2 || cross.Sources[0].Pair != "EUR/USD" || cross.Sources[1].Pair != "USD/JPY" {
+		t.Errorf("sources = %+v, want EUR/USD then USD/JPY", cross.Sources)
+	}
+	if !cross.Timestamp.Equal(jpy.Timestamp) {
+		t.Errorf("timestamp %s, want the older leg's %s", cross.Timestamp, jpy.Timestamp)
+	}
+
+	stored, err := m.GetSpotRate("USD/EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Derived || stored.Sources != nil {
+		t.Errorf("inverted stored rate %+v is marked derived", stored)
+	}
+}
+
+func TestCrossShortestPath(t *testing.T) {
+	// EUR -> GBP -> CHF -> CAD, with no USD legs for the vehicle route
+	m := NewManager(zap.NewNop())
+	for pair, rate := range map[string]float64{"EUR/GBP": 0.85, "GBP/CHF": 1.1, "CAD/CHF": 0.65, "AUD/NZD": 1.08} {
+		if err := m.UpdateSpotRate(pair, rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.GetSpotRate("EUR/CAD"); err == nil {
+		t.Fatal("vehicle routing should not find EUR/CAD without USD legs")
+	}
+
+	if err := m.SetCrossRouting(CrossShortestPath); err != nil {
+		t.Fatal(err)
+	}
+	cross, err := m.GetSpotRate("EUR/CAD")
+	if err != nil {
+		t.Fatalf("GetSpotRate: %v", err)
+	}
+	if want := 0.85 * 1.1 / 0.65; math.Abs(cross.Rate-want) > 1e-12 {
+		t.Errorf("EUR/CAD = %g, want %g", cross.Rate, want)
+	}
+	var legs []string
+	for _, leg := range cross.Sources {
+		legs = append(legs, leg.Pair)
+	}
+	if len(legs) != 3 || legs[0] != "EUR/GBP" || legs[1] != "GBP/CHF" || legs[2] != "CHF/CAD" {
+		t.Errorf("legs = %v, want [EUR/GBP GBP/CHF CHF/CAD]", legs)
+	}
+
+	// A direct pair added later shortens the route
+	if err := m.UpdateSpotRate("EUR/CHF", 0.93); err != nil {
+		t.Fatal(err)
+	}
+	if cross, err = m.GetSpotRate("EUR/CAD"); err != nil {
+		t.Fatal(err)
+	}
+	if len(cross.Sources) != 2 || math.Abs(cross.Rate-0.93/0.65) > 1e-12 {
+		t.Errorf("EUR/CAD = %g via %d legs, want %g via 2", cross.Rate, len(cross.Sources), 0.93/0.65)
+	}
+
+	if _, err := m.GetSpotRate("EUR/AUD"); err == nil {
+		t.Error("EUR/AUD has no path and should not be found")
+	}
+}
+
+func TestCrossDisabled(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	for pair, rate := range map[string]float64{"EUR/USD": 1.1, "USD/JPY": 150} {
+		if err := m.UpdateSpotRate(pair, rate); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.SetCrossRouting(CrossDisabled); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.GetSpotRate("EUR/JPY"); err == nil {
+		t.Error("EUR/JPY should not be derived with routing disabled")
+	}
+	if spot, err := m.GetSpotRate("JPY/USD"); err != nil || math.Abs(spot.Rate-1.0/150) > 1e-15 {
+		t.Errorf("JPY/USD = %+v, %v, want the inverted stored rate", spot, err)
+	}
+}
@@ -0,0 +1,335 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Pillar value types
+const (
+	PillarZeroRate       = "zero_rate" // zero rate in the curve's convention
+	PillarDiscountFactor = "discount_factor"
+)
+
+// Interpolation methods for pillar curves. Zero rates are interpolated as
+// their continuously compounded equivalents. Before the first pillar every
+// method holds the first zero rate flat; after the last pillar flat_forward
+// extends the last forward and the others hold the last zero rate flat.
+const (
+	// InterpLinearZero is linear in zero rate; "linear" is an alias
+	InterpLinearZero = "linear_zero"
+	// InterpLogLinear is linear in log discount factor
+	InterpLogLinear = "log_linear"
+	// InterpMonotoneCubic is a Fritsch-Carlson monotone cubic spline on
+	// -ln(DF), so discount factors stay monotone when pillars are; "cubic" is
+	// an alias
+	InterpMonotoneCubic = "monotone_cubic"
+	// InterpFlatForward holds the instantaneous forward constant between
+	// pillars. It matches log_linear between pillars and differs only when
+	// extrapolating past the last one.
+	InterpFlatForward = "flat_forward"
+)
+
+var tenorPattern = regexp.MustCompile(`^(\d+)([DWMY])$`)
+
+// Convention returns the compounding and day count the curve's rates are
+// quoted in
+func (c DiscountCurve) Convention() RateConvention {
+	return RateConvention{Compounding: c.Compounding, DayCount: c.DayCount}
+}
+
+// YearFraction returns the time from the curve's reference date in the
+// curve's day count
+func (c DiscountCurve) YearFraction(date time.Time) float64 {
+	return c.DayCount.YearFraction(c.ReferenceDate, date)
+}
+
+// DiscountFactor returns the discount factor for time t in years, measured
+// in the curve's day count
+func (c DiscountCurve) DiscountFactor(t float64) (float64, error) {
+	if t < 0 {
+		return 0, fmt.Errorf("curve %s: time %f is before the reference date", c.Currency, t)
+	}
+	model, err := c.model()
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(-model.logDiscount(t)), nil
+}
+
+// ZeroRate returns the zero rate for time t in years, in the curve's
+// convention. For t <= 0 it returns the overnight rate.
+func (c DiscountCurve) ZeroRate(t float64) (float64, error) {
+	model, err := c.model()
+	if err != nil {
+		return 0, err
+	}
+	if t <= 0 {
+		t = c.DayCount.YearFraction(c.ReferenceDate, c.ReferenceDate.AddDate(0, 0, 1))
+	}
+	return c.Compounding.Rate(math.Exp(-model.logDiscount(t)), t), nil
+}
+
+// ZeroRateIn returns the zero rate from the reference date to date, quoted
+// in another convention
+func (c DiscountCurve) ZeroRateIn(date time.Time, convention RateConvention) (float64, error) {
+	if err := convention.Validate(); err != nil {
+		return 0, fmt.Errorf("curve %s: %w", c.Currency, err)
+	}
+	t := convention.DayCount.YearFraction(c.ReferenceDate, date)
+	if t <= 0 {
+		return 0, fmt.Errorf("curve %s: %s is not after the reference date", c.Currency, date.Format("2006-01-02"))
+	}
+	df, err := c.DiscountFactor(c.YearFraction(date))
+	if err != nil {
+		return 0, err
+	}
+	return convention.Compounding.Rate(df, t), nil
+}
+
+// ForwardRate returns the forward rate between t1 and t2 in years, in the
+// curve's convention
+func (c DiscountCurve) ForwardRate(t1, t2 float64) (float64, error) {
+	if t1 < 0 || t2 <= t1 {
+		return 0, fmt.Errorf("curve %s: invalid forward period [%f, %f]", c.Currency, t1, t2)
+	}
+	model, err := c.model()
+	if err != nil {
+		return 0, err
+	}
+	df := math.Exp(model.logDiscount(t1) - model.logDiscount(t2))
+	return c.Compounding.Rate(df, t2-t1), nil
+}
+
+// Validate checks that the curve can be evaluated
+func (c DiscountCurve) Validate() error {
+	if err := c.Convention().Validate(); err != nil {
+		return fmt.Errorf("curve %s: %w", c.Currency, err)
+	}
+	_, err := c.model()
+	return err
+}
+
+// ResolvedPillars returns the pillars sorted by date, with tenors resolved
+// against the reference date
+func (c DiscountCurve) ResolvedPillars() ([]CurvePillar, error) {
+	pillars := make([]CurvePillar, len(c.Pillars))
+	for i, pillar := range c.Pillars {
+		if pillar.Date.IsZero() {
+			if c.ReferenceDate.IsZero() {
+				return nil, fmt.Errorf("pillar %d: tenor %q needs a reference date", i, pillar.Tenor)
+			}
+			date, err := addTenor(c.ReferenceDate, pillar.Tenor)
+			if err != nil {
+				return nil, fmt.Errorf("pillar %d: %w", i, err)
+			}
+			pillar.Date = date
+		}
+		pillars[i] = pillar
+	}
+
+	sort.SliceStable(pillars, func(i, j int) bool {
+		return pillars[i].Date.Before(pillars[j].Date)
+	})
+	return pillars, nil
+}
+
+// addTenor adds a tenor such as "3M" to a date
+func addTenor(date time.Time, tenor string) (time.Time, error) {
+	match := tenorPattern.FindStringSubmatch(tenor)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("invalid tenor %q: expected e.g. 1W, 6M, 10Y", tenor)
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid tenor %q: %w", tenor, err)
+	}
+
+	switch match[2] {
+	case "D":
+		return date.AddDate(0, 0, n), nil
+	case "W":
+		return date.AddDate(0, 0, 7*n), nil
+	case "M":
+		return date.AddDate(0, n, 0), nil
+	default:
+		return date.AddDate(n, 0, 0), nil
+	}
+}
+
+// curveModel evaluates y(t) = -ln DF(t)
+type curveModel struct {
+	method      string
+	rate        float64     // flat curves: quoted rate
+	compounding Compounding // flat curves: compounding of rate
+	times       []float64   // pillar times, strictly increasing
+	ys          []float64   // -ln DF at each pillar
+	slopes      []float64   // spline tangents dy/dt at each pillar
+}
+
+func (c DiscountCurve) model() (*curveModel, error) {
+	if err := c.Convention().Validate(); err != nil {
+		return nil, fmt.Errorf("curve %s: %w", c.Currency, err)
+	}
+	if len(c.Pillars) == 0 {
+		return &curveModel{method: "flat", rate: c.FlatRate, compounding: c.Compounding}, nil
+	}
+
+	if c.ReferenceDate.IsZero() {
+		return nil, fmt.Errorf("curve %s: pillar curves need a reference date", c.Currency)
+	}
+	method, err := normalizeInterpolation(c.Interpolation)
+	if err != nil {
+		return nil, fmt.Errorf("curve %s: %w", c.Currency, err)
+	}
+	pillars, err := c.ResolvedPillars()
+	if err != nil {
+		return nil, fmt.Errorf("curve %s: %w", c.Currency, err)
+	}
+
+	model := &curveModel{method: method}
+	for i, pillar := range pillars {
+		t := c.YearFraction(pillar.Date)
+		if t <= 0 {
+			return nil, fmt.Errorf("curve %s: pillar %s is not after the reference date",
+				c.Currency, pillar.Date.Format("2006-01-02"))
+		}
+		if i > 0 && t == model.times[i-1] {
+			return nil, fmt.Errorf("curve %s: duplicate pillar %s", c.Currency, pillar.Date.Format("2006-01-02"))
+		}
+		if math.IsNaN(pillar.Value) || math.IsInf(pillar.Value, 0) {
+			return nil, fmt.Errorf("curve %s: pillar %s value must be finite", c.Currency, pillar.Date.Format("2006-01-02"))
+		}
+
+		var y float64
+		switch c.PillarType {
+		case PillarZeroRate, "":
+			df := c.Compounding.DiscountFactor(pillar.Value, t)
+			if df <= 0 || math.IsInf(df, 0) {
+				return nil, fmt.Errorf("curve %s: zero rate %f at %s gives no discount factor",
+					c.Currency, pillar.Value, pillar.Date.Format("2006-01-02"))
+			}
+			y = -math.Log(df)
+		case PillarDiscountFactor:
+			if pillar.Value <= 0 {
+				return nil, fmt.Errorf("curve %s: discount factor %f at %s must be positive",
+					c.Currency, pillar.Value, pillar.Date.Format("2006-01-02"))
+			}
+			y = -math.Log(pillar.Value)
+		default:
+			return nil, fmt.Errorf("curve %s: unknown pillar type %q", c.Currency, c.PillarType)
+		}
+
+		model.times = append(model.times, t)
+		model.ys = append(model.ys, y)
+	}
+
+	if method == InterpMonotoneCubic {
+		model.slopes = monotoneSlopes(model.times, model.ys)
+	}
+	return model, nil
+}
+
+// normalizeInterpolation resolves aliases; an empty method means linear_zero
+func normalizeInterpolation(method string) (string, error) {
+	switch method {
+	case InterpLinearZero, "linear", "":
+		return InterpLinearZero, nil
+	case InterpMonotoneCubic, "cubic":
+		return InterpMonotoneCubic, nil
+	case InterpLogLinear, InterpFlatForward:
+		return method, nil
+	}
+	return "", fmt.Errorf("unknown interpolation %q", method)
+}
+
+// logDiscount returns -ln DF(t)
+func (m *curveModel) logDiscount(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if m.method == "flat" {
+		return -math.Log(m.compounding.DiscountFactor(m.rate, t))
+	}
+
+	n := len(m.times)
+	first, last := m.times[0], m.times[n-1]
+	switch {
+	case t <= first:
+		return m.ys[0] / first * t
+
+	case t >= last:
+		if m.method == InterpFlatForward {
+			prevT, prevY := 0.0, 0.0
+			if n > 1 {
+				prevT, prevY = m.times[n-2], m.ys[n-2]
+			}
+			forward := (m.ys[n-1] - prevY) / (last - prevT)
+			return m.ys[n-1] + forward*(t-last)
+		}
+		return m.ys[n-1] / last * t
+	}
+
+	// Segment k with times[k] < t < times[k+1]
+	k := sort.SearchFloat64s(m.times, t) - 1
+	t0, t1 := m.times[k], m.times[k+1]
+	y0, y1 := m.ys[k], m.ys[k+1]
+	s := (t - t0) / (t1 - t0)
+
+	switch m.method {
+	case InterpLinearZero:
+		r0, r1 := y0/t0, y1/t1
+		return (r0 + s*(r1-r0)) * t
+
+	case InterpMonotoneCubic:
+		h := t1 - t0
+		h00 := 2*s*s*s - 3*s*s + 1
+		h10 := s*s*s - 2*s*s + s
+		h01 := -2*s*s*s + 3*s*s
+		h11 := s*s*s - s*s
+		return h00*y0 + h10*h*m.slopes[k] + h01*y1 + h11*h*m.slopes[k+1]
+
+	default: // log_linear, flat_forward
+		return y0 + s*(y1-y0)
+	}
+}
+
+// monotoneSlopes computes Fritsch-Carlson tangents, which keep the spline
+// monotone wherever the data is
+func monotoneSlopes(xs, ys []float64) []float64 {
+	n := len(xs)
+	slopes := make([]float64, n)
+	if n < 2 {
+		return slopes
+	}
+
+	deltas := make([]float64, n-1)
+	for k := 0; k < n-1; k++ {
+		deltas[k] = (ys[k+1] - ys[k]) / (xs[k+1] - xs[k])
+	}
+
+	slopes[0], slopes[n-1] = deltas[0], deltas[n-2]
+	for k := 1; k < n-1; k++ {
+		if deltas[k-1]*deltas[k] > 0 {
+			slopes[k] = (deltas[k-1] + deltas[k]) / 2
+		}
+	}
+
+	for k := 0; k < n-1; k++ {
+		if deltas[k] == 0 {
+			slopes[k], slopes[k+1] = 0, 0
+			continue
+		}
+		a, b := slopes[k]/deltas[k], slopes[k+1]/deltas[k]
+		if norm := a*a + b*b; norm > 9 {
+			tau := 3 / math.Sqrt(norm)
+			slopes[k] = tau * a * deltas[k]
+			slopes[k+1] = tau * b * deltas[k]
+		}
+	}
+	return slopes
+}
@@ -0,0 +1,143 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func testPillarCurve(interpolation, pillarType string) DiscountCurve {
+	values := []float64{0.030, 0.032, 0.035, 0.034, 0.036}
+	if pillarType == PillarDiscountFactor {
+		for i, t := range []float64{0.25, 1, 2, 5, 10} {
+			values[i] = math.Exp(-values[i] * t)
+		}
+	}
+	tenors := []string{"3M", "1Y", "2Y", "5Y", "10Y"}
+	pillars := make([]CurvePillar, len(tenors))
+	for i, tenor := range tenors {
+		pillars[i] = CurvePillar{Tenor: tenor, Value: values[i]}
+	}
+	return DiscountCurve{
+		Currency:      "USD",
+		ReferenceDate: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		Pillars:       pillars,
+		PillarType:    pillarType,
+		Interpolation: interpolation,
+	}
+}
+
+func TestPillarCurvesRepricePillars(t *testing.T) {
+	methods := []string{InterpLinearZero, InterpLogLinear, InterpMonotoneCubic, InterpFlatForward}
+	for _, method := range methods {
+		for _, pillarType := range []string{PillarZeroRate, PillarDiscountFactor} {
+			t.Run(method+"/"+pillarType, func(t *testing.T) {
+				curve := testPillarCurve(method, pillarType)
+				pillars, err := curve.ResolvedPillars()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, pillar := range pillars {
+					tp := curve.YearFraction(pillar.Date)
+					df, err := curve.DiscountFactor(tp)
+					if err != nil {
+						t.Fatal(err)
+					}
+					want := pillar.Value
+					if pillarType == PillarZeroRate {
+						want = math.Exp(-pillar.Value * tp)
+					}
+					if math.Abs(df-want) > 1e-12 {
+						t.Errorf("DF(%s) = %.12f, want %.12f", pillar.Date.Format("2006-01-02"), df, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPillarCurveForwardsAreConsistent(t *testing.T) {
+	for _, method := range []string{InterpLinearZero, InterpLogLinear, InterpMonotoneCubic, InterpFlatForward} {
+		t.Run(method, func(t *testing.T) {
+			curve := testPillarCurve(method, PillarZeroRate)
+			previous := 1.0
+			for t1 := 0.1; t1 < 15; t1 += 0.1 {
+				t2 := t1 + 0.1
+				df1, _ := curve.DiscountFactor(t1)
+				df2, _ := curve.DiscountFactor(t2)
+				if df1 > previous+1e-15 {
+					t.Fatalf("DF rises at t=%.1f: %f > %f", t1, df1, previous)
+				}
+				previous = df1
+
+				forward, err := curve.ForwardRate(t1, t2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := math.Log(df1/df2) / (t2 - t1); math.Abs(forward-want) > 1e-12 {
+					t.Fatalf("ForwardRate(%.1f, %.1f) = %f, want %f", t1, t2, forward, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPillarCurveExtrapolation(t *testing.T) {
+	flat := testPillarCurve(InterpLogLinear, PillarZeroRate)
+	for _, tt := range []float64{12, 20, 30} {
+		zero, err := flat.ZeroRate(tt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(zero-0.036) > 1e-12 {
+			t.Errorf("log_linear ZeroRate(%v) = %f, want the last pillar's 0.036", tt, zero)
+		}
+	}
+
+	forward := testPillarCurve(InterpFlatForward, PillarZeroRate)
+	pillars, err := forward.ResolvedPillars()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(pillars)
+	lastForward, err := forward.ForwardRate(forward.YearFraction(pillars[n-2].Date), forward.YearFraction(pillars[n-1].Date))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []float64{12, 20, 30} {
+		f, err := forward.ForwardRate(tt, tt+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(f-lastForward) > 1e-9 {
+			t.Errorf("flat_forward ForwardRate(%v) = %f, want the last forward %f", tt, f, lastForward)
+		}
+	}
+
+	// Before the first pillar every method holds the first zero rate
+	for _, method := range []string{InterpLinearZero, InterpLogLinear, InterpMonotoneCubic, InterpFlatForward} {
+		zero, err := testPillarCurve(method, PillarZeroRate).ZeroRate(0.1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(zero-0.03) > 1e-12 {
+			t.Errorf("%s ZeroRate(0.1) = %f, want 0.03", method, zero)
+		}
+	}
+}
+
+func TestLinearZeroInterpolatesZeroRates(t *testing.T) {
+	curve := testPillarCurve(InterpLinearZero, PillarZeroRate)
+	t1 := curve.YearFraction(time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC))
+	t2 := curve.YearFraction(time.Date(2028, 1, 15, 0, 0, 0, 0, time.UTC))
+
+	mid := (t1 + t2) / 2
+	zero, err := curve.ZeroRate(mid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(zero-0.0335) > 1e-12 {
+		t.Fatalf("ZeroRate(%f) = %f, want 0.0335", mid, zero)
+	}
+}
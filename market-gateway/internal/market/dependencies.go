@@ -0,0 +1,282 @@
+package market
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// Dependencies lists the market data a contract needs to be priced.
+// Keys use the snapshot conventions: pairs as "EUR/USD" (foreign/domestic),
+// curves by currency code. Each list is sorted by key.
+type Dependencies struct {
+	SpotRates      []string
+	DiscountCurves []CurveDependency
+	VolSurfaces    []VolDependency
+}
+
+// CurveDependency is a discount curve and the maturities it is read at
+type CurveDependency struct {
+	Currency   string
+	Maturities []time.Time // sorted, distinct dates
+}
+
+// VolDependency is a vol surface and the strikes and maturities it is read at
+type VolDependency struct {
+	Pair       string
+	Strikes    []float64   // sorted, distinct; includes barrier levels
+	Maturities []time.Time // sorted, distinct dates
+}
+
+// MissingKey is a market data key that a contract needs but a snapshot lacks
+type MissingKey struct {
+	Kind string // "spot_rate", "discount_curve" or "vol_surface"
+	Key  string
+}
+
+func (k MissingKey) String() string {
+	return fmt.Sprintf("%s %s", k.Kind, k.Key)
+}
+
+// MissingDataError reports every market data key missing from a snapshot
+type MissingDataError struct {
+	Missing []MissingKey
+}
+
+func (e *MissingDataError) Error() string {
+	keys := make([]string, len(e.Missing))
+	for i, k := range e.Missing {
+		keys[i] = k.String()
+	}
+	return fmt.Sprintf("market snapshot is missing %d keys: %s", len(e.Missing), strings.Join(keys, ", "))
+}
+
+// AnalyzeDependencies returns the market data a contract needs under the
+// Garman-Kohlhagen model:
+//
+//	Spot       spot rate
+//	Forward    spot rate, domestic and foreign curves at maturity
+//	EurOption  spot rate, both curves and the vol surface at (strike, maturity)
+//	ZCB        the currency's curve at maturity
+//	When       the guarded contract, plus the observables in its condition;
+//	           a barrier also needs its underlying pair's vol at the level
+func AnalyzeDependencies(c models.Contract) (Dependencies, error) {
+	collector := newDependencyCollector()
+	if _, err := models.Fold[struct{}](c, collector); err != nil {
+		return Dependencies{}, fmt.Errorf("failed to analyze market dependencies: %w", err)
+	}
+	return collector.dependencies(), nil
+}
+
+// Missing returns the dependency keys absent from a snapshot, spot rates
+// first, then discount curves, then vol surfaces. Spot rates and vol surfaces
+// may be stored in either orientation, as for Manager lookups.
+func (d Dependencies) Missing(snapshot MarketSnapshot) []MissingKey {
+	var missing []MissingKey
+	for _, pair := range d.SpotRates {
+		if !hasPair(snapshot.SpotRates, pair) {
+			missing = append(missing, MissingKey{Kind: "spot_rate", Key: pair})
+		}
+	}
+	for _, curve := range d.DiscountCurves {
+		if _, ok := snapshot.DiscountCurves[curve.Currency]; !ok {
+			missing = append(missing, MissingKey{Kind: "discount_curve", Key: curve.Currency})
+		}
+	}
+	for _, surface := range d.VolSurfaces {
+		if !hasPair(snapshot.VolSurfaces, surface.Pair) {
+			missing = append(missing, MissingKey{Kind: "vol_surface", Key: surface.Pair})
+		}
+	}
+	return missing
+}
+
+// hasPair reports whether a pair-keyed snapshot map holds a pair in either
+// orientation
+func hasPair[V any](entries map[string]V, pair string) bool {
+	if _, ok := entries[pair]; ok {
+		return true
+	}
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return false
+	}
+	_, ok := entries[currencyPair.Invert().String()]
+	return ok
+}
+
+// Check returns a *MissingDataError if the snapshot lacks any dependency
+func (d Dependencies) Check(snapshot MarketSnapshot) error {
+	if missing := d.Missing(snapshot); len(missing) > 0 {
+		return &MissingDataError{Missing: missing}
+	}
+	return nil
+}
+
+// dependencyCollector accumulates dependencies while folding a contract
+type dependencyCollector struct {
+	spots  map[string]bool
+	curves map[string]map[string]time.Time // currency -> date -> maturity
+	vols   map[string]*volUsage
+}
+
+type volUsage struct {
+	strikes    map[float64]bool
+	maturities map[string]time.Time
+}
+
+func newDependencyCollector() *dependencyCollector {
+	return &dependencyCollector{
+		spots:  make(map[string]bool),
+		curves: make(map[string]map[string]time.Time),
+		vols:   make(map[string]*volUsage),
+	}
+}
+
+func (d *dependencyCollector) VisitZero(string, models.Zero) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitSpot(_ string, c models.Spot) (struct{}, error) {
+	d.spot(c.Domestic, c.Foreign)
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitForward(_ string, c models.Forward) (struct{}, error) {
+	d.spot(c.Domestic, c.Foreign)
+	d.curve(c.Domestic, c.Maturity)
+	d.curve(c.Foreign, c.Maturity)
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitEurOption(_ string, c models.EurOption) (struct{}, error) {
+	d.spot(c.Domestic, c.Foreign)
+	d.curve(c.Domestic, c.Maturity)
+	d.curve(c.Foreign, c.Maturity)
+	d.vol(c.Domestic, c.Foreign).strikes[c.Strike] = true
+	d.vol(c.Domestic, c.Foreign).maturities[c.Maturity.Format("2006-01-02")] = c.Maturity
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitZCB(_ string, c models.ZCB) (struct{}, error) {
+	d.curve(c.Currency, c.Maturity)
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitScale(string, models.Scale, struct{}) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitCombine(string, models.Combine, struct{}, struct{}) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitWhen(_ string, c models.When, _ struct{}) (struct{}, error) {
+	d.observable(c.Condition)
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) VisitNamed(string, models.Named, struct{}) (struct{}, error) {
+	return struct{}{}, nil
+}
+
+func (d *dependencyCollector) observable(o models.Observable) {
+	switch o := o.(type) {
+	case models.SpotRate:
+		d.spot(o.Domestic, o.Foreign)
+
+	case models.FwdRate:
+		d.spot(o.Domestic, o.Foreign)
+		d.curve(o.Domestic, o.Maturity)
+		d.curve(o.Foreign, o.Maturity)
+
+	case models.Barrier:
+		d.observable(o.Underlying)
+		// Hitting probabilities need the underlying pair's vol at the barrier
+		switch u := o.Underlying.(type) {
+		case models.SpotRate:
+			d.vol(u.Domestic, u.Foreign).strikes[o.Level] = true
+		case models.FwdRate:
+			d.vol(u.Domestic, u.Foreign).strikes[o.Level] = true
+		}
+	}
+}
+
+func (d *dependencyCollector) spot(domestic, foreign models.Currency) {
+	d.spots[pairKey(domestic, foreign)] = true
+}
+
+func (d *dependencyCollector) curve(currency models.Currency, maturity time.Time) {
+	key := currency.String()
+	if d.curves[key] == nil {
+		d.curves[key] = make(map[string]time.Time)
+	}
+	d.curves[key][maturity.Format("2006-01-02")] = maturity
+}
+
+func (d *dependencyCollector) vol(domestic, foreign models.Currency) *volUsage {
+	key := pairKey(domestic, foreign)
+	if d.vols[key] == nil {
+		d.vols[key] = &volUsage{strikes: make(map[float64]bool), maturities: make(map[string]time.Time)}
+	}
+	return d.vols[key]
+}
+
+func (d *dependencyCollector) dependencies() Dependencies {
+	var deps Dependencies
+	for pair := range d.spots {
+		deps.SpotRates = append(deps.SpotRates, pair)
+	}
+	sort.Strings(deps.SpotRates)
+
+	for currency, maturities := range d.curves {
+		deps.DiscountCurves = append(deps.DiscountCurves, CurveDependency{
+			Currency:   currency,
+			Maturities: sortedDates(maturities),
+		})
+	}
+	sort.Slice(deps.DiscountCurves, func(i, j int) bool {
+		return deps.DiscountCurves[i].Currency < deps.DiscountCurves[j].Currency
+	})
+
+	for pair, usage := range d.vols {
+		strikes := make([]float64, 0, len(usage.strikes))
+		for strike := range usage.strikes {
+			strikes = append(strikes, strike)
+		}
+		sort.Float64s(strikes)
+		deps.VolSurfaces = append(deps.VolSurfaces, VolDependency{
+			Pair:       pair,
+			Strikes:    strikes,
+			Maturities: sortedDates(usage.maturities),
+		})
+	}
+	sort.Slice(deps.VolSurfaces, func(i, j int) bool {
+		return deps.VolSurfaces[i].Pair < deps.VolSurfaces[j].Pair
+	})
+
+	return deps
+}
+
+// pairKey returns the snapshot key of a pair, "FOREIGN/DOMESTIC"
+func pairKey(domestic, foreign models.Currency) string {
+	return models.NewCurrencyPair(foreign, domestic).String()
+}
+
+// sortedDates returns the values of a date-keyed set in ascending order
+func sortedDates(dates map[string]time.Time) []time.Time {
+	keys := make([]string, 0, len(dates))
+	for key := range dates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]time.Time, len(keys))
+	for i, key := range keys {
+		result[i] = dates[key]
+	}
+	return result
+}
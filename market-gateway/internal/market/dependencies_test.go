@@ -0,0 +1,31 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+func TestMissingAcceptsEitherOrientation(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	deps, err := AnalyzeDependencies(models.NewCallOption(1.1, maturity, models.USD, models.EUR))
+	if err != nil {
+		t.Fatalf("AnalyzeDependencies: %v", err)
+	}
+
+	snapshot := MarketSnapshot{
+		SpotRates:      map[string]SpotRate{"USD/EUR": {Pair: "USD/EUR", Rate: 0.9}},
+		DiscountCurves: map[string]DiscountCurve{"USD": {Currency: "USD"}, "EUR": {Currency: "EUR"}},
+		VolSurfaces:    map[string]VolSurface{"USD/EUR": {Pair: "USD/EUR", FlatVol: 0.1}},
+	}
+	if missing := deps.Missing(snapshot); len(missing) != 0 {
+		t.Fatalf("Missing = %v, want none", missing)
+	}
+
+	delete(snapshot.VolSurfaces, "USD/EUR")
+	missing := deps.Missing(snapshot)
+	if len(missing) != 1 || missing[0] != (MissingKey{Kind: "vol_surface", Key: "EUR/USD"}) {
+		t.Fatalf("Missing = %v, want vol_surface EUR/USD", missing)
+	}
+}
@@ -0,0 +1,144 @@
+package market
+
+import (
+	"fmt"
+	"math"
+)
+
+// levenbergMarquardt minimises the sum of squared residuals f(x) from x0,
+// using a forward-difference Jacobian. Parameters are unconstrained; callers
+// map bounded parameters through transforms such as exp and tanh.
+func levenbergMarquardt(f func(x []float64) []float64, x0 []float64) ([]float64, error) {
+	const (
+		maxIterations = 500
+		tolerance     = 1e-14
+	)
+
+	x := append([]float64(nil), x0...)
+	r := f(x)
+	cost := sumSquares(r)
+	if math.IsNaN(cost) || math.IsInf(cost, 0) {
+		return nil, fmt.Errorf("residuals are not finite at the initial guess")
+	}
+
+	n, m := len(x), len(r)
+	lambda := 1e-3
+	for iteration := 0; iteration < maxIterations && cost > tolerance; iteration++ {
+		jacobian := make([][]float64, m)
+		for i := range jacobian {
+			jacobian[i] = make([]float64, n)
+		}
+		for j := 0; j < n; j++ {
+			h := 1e-7 * math.Max(1, math.Abs(x[j]))
+			shifted := append([]float64(nil), x...)
+			shifted[j] += h
+			rShifted := f(shifted)
+			for i := 0; i < m; i++ {
+				jacobian[i][j] = (rShifted[i] - r[i]) / h
+			}
+		}
+
+		// Normal equations J'J δ = -J'r
+		jtj := make([][]float64, n)
+		jtr := make([]float64, n)
+		for a := 0; a < n; a++ {
+			jtj[a] = make([]float64, n)
+			for b := 0; b < n; b++ {
+				for i := 0; i < m; i++ {
+					jtj[a][b] += jacobian[i][a] * jacobian[i][b]
+				}
+			}
+			for i := 0; i < m; i++ {
+				jtr[a] -= jacobian[i][a] * r[i]
+			}
+		}
+
+		improved := false
+		for !improved && lambda < 1e12 {
+			damped := make([][]float64, n)
+			for a := range jtj {
+				damped[a] = append([]float64(nil), jtj[a]...)
+				damped[a][a] += lambda * (jtj[a][a] + 1e-12)
+			}
+			step, ok := solveLinear(damped, jtr)
+			if !ok {
+				lambda *= 10
+				continue
+			}
+
+			trial := make([]float64, n)
+			for j := range x {
+				trial[j] = x[j] + step[j]
+			}
+			rTrial := f(trial)
+			costTrial := sumSquares(rTrial)
+			if math.IsNaN(costTrial) || costTrial >= cost {
+				lambda *= 10
+				continue
+			}
+
+			improved = true
+			converged := cost-costTrial <= tolerance*(1+cost)
+			x, r, cost = trial, rTrial, costTrial
+			lambda = math.Max(lambda/10, 1e-12)
+			if converged {
+				return x, nil
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return x, nil
+}
+
+func sumSquares(r []float64) float64 {
+	total := 0.0
+	for _, v := range r {
+		total += v * v
+	}
+	return total
+}
+
+// solveLinear solves a x = b by Gaussian elimination with partial pivoting,
+// reporting false when a is singular
+func solveLinear(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	a = append([][]float64(nil), a...)
+	b = append([]float64(nil), b...)
+	for i := range a {
+		a[i] = append([]float64(nil), a[i]...)
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-300 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
@@ -6,30 +6,118 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
 )
 
 // SpotRate represents a currency pair spot rate
 type SpotRate struct {
-	Pair      string    // e.g., "EUR/USD"
-	Rate      float64   // e.g., 1.1050
-	Timestamp time.Time // Last update time
+	Pair      string     // e.g., "EUR/USD"
+	Rate      float64    // Mid rate, e.g., 1.1050
+	Bid       float64    // Two-way quote, zero when only the mid is known
+	Ask       float64
+	Source    string     // Quote source, e.g. "EBS"
+	Sequence  uint64     // Per-source sequence number
+	Derived   bool       // True for crosses triangulated from other pairs
+	Sources   []SpotRate // Legs a derived rate was computed from, oriented along the path
+	Timestamp time.Time  // Last update time; the oldest source's for derived rates
+}
+
+// SpotQuote is a two-way spot price published by a source
+type SpotQuote struct {
+	Bid      float64
+	Ask      float64
+	Source   string
+	Sequence uint64 // Must increase per source; 0 disables the check
+}
+
+// QuoteSide selects which side of a two-way price to use
+type QuoteSide int
+
+const (
+	QuoteMid QuoteSide = iota
+	QuoteBid           // price at which the market buys the base currency
+	QuoteAsk           // price at which the market sells the base currency
+)
+
+// Price returns the bid, ask or mid rate. One-way rates return the mid for
+// every side.
+func (s SpotRate) Price(side QuoteSide) float64 {
+	if !s.IsTwoWay() {
+		return s.Rate
+	}
+	switch side {
+	case QuoteBid:
+		return s.Bid
+	case QuoteAsk:
+		return s.Ask
+	}
+	return s.Rate
+}
+
+// IsTwoWay reports whether the rate carries a bid and an ask
+func (s SpotRate) IsTwoWay() bool {
+	return s.Bid > 0 && s.Ask > 0
+}
+
+// Spread returns ask minus bid, zero for one-way rates
+func (s SpotRate) Spread() float64 {
+	if !s.IsTwoWay() {
+		return 0
+	}
+	return s.Ask - s.Bid
+}
+
+// ValidateQuote checks that a two-way quote is positive and not crossed
+func ValidateQuote(bid, ask float64) error {
+	if bid <= 0 || ask <= 0 {
+		return fmt.Errorf("invalid quote %f/%f: bid and ask must be positive", bid, ask)
+	}
+	if bid > ask {
+		return fmt.Errorf("invalid quote %f/%f: bid must not exceed ask", bid, ask)
+	}
+	return nil
 }
 
-// DiscountCurve represents a discount curve for a currency
-// TODO: Expand to support pillar points and interpolation methods
+// DiscountCurve represents a discount curve for a currency: a flat rate, or
+// pillars interpolated as described in curve.go
 type DiscountCurve struct {
-	Currency    string
-	FlatRate    float64 // Simplified: single flat rate for now
-	Compounding string  // "continuous", "annual", etc.
-	Timestamp   time.Time
+	Currency      string
+	FlatRate      float64       // Used when no pillars are set
+	Compounding   Compounding   // Compounding of FlatRate and zero-rate pillars
+	DayCount      DayCount      // Day count of curve times and rates
+	ReferenceDate time.Time     // Curve date, t = 0; pillar times are measured from it
+	Pillars       []CurvePillar // Optional pillar points
+	PillarType    string        // "zero_rate" or "discount_factor"
+	Interpolation string        // "linear_zero", "log_linear", "monotone_cubic", "flat_forward"
+	Timestamp     time.Time
 }
 
-// VolSurface represents a volatility surface for a currency pair
-// TODO: Expand to support volatility grids
+// CurvePillar is a single point on a discount curve, given by date or by
+// tenor from the curve's reference date
+type CurvePillar struct {
+	Date  time.Time // Takes precedence over Tenor when set
+	Tenor string    // e.g. "1W", "6M", "10Y"
+	Value float64   // Zero rate or discount factor, per the curve's PillarType
+}
+
+// VolSurface represents a volatility surface for a currency pair, either
+// flat or on a maturity × strike grid
 type VolSurface struct {
-	Pair        string
-	FlatVol     float64 // Simplified: single flat volatility for now
-	Timestamp   time.Time
+	Pair          string
+	FlatVol       float64    // Used when no grid points are set
+	ReferenceDate time.Time  // Surface date, t = 0; grid times are measured from it
+	Points        []VolPoint // Optional strike/maturity grid
+	Interpolation string     // Strike interpolation: "linear", "cubic", "SABR" or "SVI"
+	Fits          []SmileFit // Calibrated smiles per expiry, for SABR and SVI
+	Timestamp     time.Time
+}
+
+// VolPoint is a single (strike, maturity) volatility quote
+type VolPoint struct {
+	Strike     float64
+	Maturity   time.Time
+	Volatility float64
 }
 
 // MarketSnapshot represents a point-in-time view of market data
@@ -37,6 +125,7 @@ type MarketSnapshot struct {
 	SpotRates       map[string]SpotRate       // Key: "EUR/USD"
 	DiscountCurves  map[string]DiscountCurve  // Key: "USD"
 	VolSurfaces     map[string]VolSurface     // Key: "EUR/USD"
+	Correlations    map[string]float64        // Key: "EUR/USD/GBP/USD"
 	SnapshotTime    time.Time
 }
 
@@ -46,6 +135,10 @@ type Manager struct {
 	spotRates       map[string]SpotRate
 	discountCurves  map[string]DiscountCurve
 	volSurfaces     map[string]VolSurface
+	correlations    map[string]float64 // Key: "EUR/USD/GBP/USD"
+	vehicle         models.Currency
+	crossRouting    CrossRouting
+	arbitragePolicy ArbitragePolicy
 	logger          *zap.Logger
 }
 
@@ -59,19 +152,32 @@ func NewManager(logger *zap.Logger) *Manager {
 		spotRates:      make(map[string]SpotRate),
 		discountCurves: make(map[string]DiscountCurve),
 		volSurfaces:    make(map[string]VolSurface),
+		correlations:   make(map[string]float64),
+		vehicle:        models.USD,
+		crossRouting:   CrossViaVehicle,
 		logger:         logger,
 	}
 }
 
-// UpdateSpotRate updates a spot rate for a currency pair
+// UpdateSpotRate updates a spot rate for a currency pair with a one-way mid
+// rate. The pair is stored in the orientation given, e.g. "EUR/USD",
+// replacing any rate stored for the inverse pair.
 func (m *Manager) UpdateSpotRate(pair string, rate float64) error {
 	if rate <= 0 {
 		return fmt.Errorf("invalid rate %f for pair %s: must be positive", rate, pair)
 	}
 
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return err
+	}
+	pair = currencyPair.String()
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// One orientation per pair, so the newest rate is the one read back
+	delete(m.spotRates, currencyPair.Invert().String())
 	m.spotRates[pair] = SpotRate{
 		Pair:      pair,
 		Rate:      rate,
@@ -86,24 +192,92 @@ func (m *Manager) UpdateSpotRate(pair string, rate float64) error {
 	return nil
 }
 
-// GetSpotRate retrieves a spot rate for a currency pair
+// UpdateSpotQuote updates a spot rate for a currency pair with a two-way
+// quote; the mid is the average of bid and ask. Quotes whose sequence number
+// does not increase over the stored quote from the same source, in either
+// orientation, are rejected. Any rate stored for the inverse pair is replaced.
+func (m *Manager) UpdateSpotQuote(pair string, quote SpotQuote) error {
+	if err := ValidateQuote(quote.Bid, quote.Ask); err != nil {
+		return fmt.Errorf("pair %s: %w", pair, err)
+	}
+
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return err
+	}
+	pair = currencyPair.String()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, exists := m.storedRate(currencyPair); exists && quote.Sequence != 0 &&
+		current.Source == quote.Source && quote.Sequence <= current.Sequence {
+		return fmt.Errorf("stale quote for pair %s from %q: sequence %d is not after %d",
+			pair, quote.Source, quote.Sequence, current.Sequence)
+	}
+
+	delete(m.spotRates, currencyPair.Invert().String())
+	m.spotRates[pair] = SpotRate{
+		Pair:      pair,
+		Rate:      (quote.Bid + quote.Ask) / 2,
+		Bid:       quote.Bid,
+		Ask:       quote.Ask,
+		Source:    quote.Source,
+		Sequence:  quote.Sequence,
+		Timestamp: time.Now(),
+	}
+
+	m.logger.Info("updated spot quote",
+		zap.String("pair", pair),
+		zap.Float64("bid", quote.Bid),
+		zap.Float64("ask", quote.Ask),
+		zap.String("source", quote.Source),
+		zap.Uint64("sequence", quote.Sequence),
+	)
+
+	return nil
+}
+
+// GetSpotRate retrieves a spot rate for a currency pair in either
+// orientation, inverting the stored rate when needed. Pairs that are not
+// stored are triangulated from other pairs (see SetCrossRouting).
 func (m *Manager) GetSpotRate(pair string) (SpotRate, error) {
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return SpotRate{}, err
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	spot, exists := m.spotRates[pair]
-	if !exists {
-		return SpotRate{}, fmt.Errorf("spot rate not found for pair %s", pair)
+	if spot, ok := m.storedRate(currencyPair); ok {
+		return spot, nil
+	}
+	if spot, ok := m.crossRate(currencyPair); ok {
+		return spot, nil
 	}
 
-	return spot, nil
+	return SpotRate{}, fmt.Errorf("spot rate not found for pair %s", pair)
 }
 
-// UpdateDiscountCurve updates a discount curve for a currency
+// UpdateDiscountCurve updates a discount curve for a currency with an
+// ACT/365F day count. The compounding must be a known convention, e.g.
+// "continuous" or "simple".
 func (m *Manager) UpdateDiscountCurve(currency string, flatRate float64, compounding string) error {
+	return m.UpdateDiscountCurveWithDayCount(currency, flatRate, compounding, DayCountAct365F.String())
+}
+
+// UpdateDiscountCurveWithDayCount updates a discount curve for a currency.
+// The compounding and day count must be known conventions, e.g. "simple" and
+// "ACT/360".
+func (m *Manager) UpdateDiscountCurveWithDayCount(currency string, flatRate float64, compounding, dayCount string) error {
 	if flatRate < 0 {
 		return fmt.Errorf("invalid flat rate %f for currency %s: must be non-negative", flatRate, currency)
 	}
+	convention, err := ParseRateConvention(compounding, dayCount)
+	if err != nil {
+		return fmt.Errorf("invalid discount curve for currency %s: %w", currency, err)
+	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -111,7 +285,8 @@ func (m *Manager) UpdateDiscountCurve(currency string, flatRate float64, compoun
 	m.discountCurves[currency] = DiscountCurve{
 		Currency:    currency,
 		FlatRate:    flatRate,
-		Compounding: compounding,
+		Compounding: convention.Compounding,
+		DayCount:    convention.DayCount,
 		Timestamp:   time.Now(),
 	}
 
@@ -119,6 +294,39 @@ func (m *Manager) UpdateDiscountCurve(currency string, flatRate float64, compoun
 		zap.String("currency", currency),
 		zap.Float64("flat_rate", flatRate),
 		zap.String("compounding", compounding),
+		zap.String("day_count", dayCount),
+	)
+
+	return nil
+}
+
+// UpdatePillarCurve validates and stores a pillar discount curve
+func (m *Manager) UpdatePillarCurve(curve DiscountCurve) error {
+	if len(curve.Pillars) == 0 {
+		return fmt.Errorf("curve %s has no pillars", curve.Currency)
+	}
+	if err := curve.Validate(); err != nil {
+		return err
+	}
+
+	pillars, err := curve.ResolvedPillars()
+	if err != nil {
+		return err
+	}
+	curve.Pillars = pillars
+	curve.Timestamp = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.discountCurves[curve.Currency] = curve
+
+	m.logger.Info("updated pillar curve",
+		zap.String("currency", curve.Currency),
+		zap.Int("pillars", len(pillars)),
+		zap.String("pillar_type", curve.PillarType),
+		zap.String("interpolation", curve.Interpolation),
+		zap.Stringer("convention", curve.Convention()),
 	)
 
 	return nil
@@ -137,15 +345,24 @@ func (m *Manager) GetDiscountCurve(currency string) (DiscountCurve, error) {
 	return curve, nil
 }
 
-// UpdateVolSurface updates a volatility surface for a currency pair
+// UpdateVolSurface updates a volatility surface for a currency pair,
+// replacing any surface stored for the inverse pair
 func (m *Manager) UpdateVolSurface(pair string, flatVol float64) error {
 	if flatVol < 0 || flatVol > 1 {
 		return fmt.Errorf("invalid flat volatility %f for pair %s: must be between 0 and 1", flatVol, pair)
 	}
 
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return err
+	}
+	pair = currencyPair.String()
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// One orientation per pair, so the newest surface is the one read back
+	delete(m.volSurfaces, currencyPair.Invert().String())
 	m.volSurfaces[pair] = VolSurface{
 		Pair:      pair,
 		FlatVol:   flatVol,
@@ -160,17 +377,94 @@ func (m *Manager) UpdateVolSurface(pair string, flatVol float64) error {
 	return nil
 }
 
-// GetVolSurface retrieves a volatility surface for a currency pair
+// UpdateVolGrid validates and stores a grid vol surface. The pair may be
+// given in either orientation; any surface stored for the inverse pair is
+// replaced.
+func (m *Manager) UpdateVolGrid(surface VolSurface) error {
+	if !surface.IsGrid() {
+		return fmt.Errorf("vol surface %s has no grid points", surface.Pair)
+	}
+	currencyPair, err := models.ParseCurrencyPair(surface.Pair)
+	if err != nil {
+		return err
+	}
+	surface.Pair = currencyPair.String()
+	if err := surface.Validate(); err != nil {
+		return err
+	}
+	if err := m.checkArbitrage(surface); err != nil {
+		return err
+	}
+	surface.Timestamp = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.volSurfaces, currencyPair.Invert().String())
+	m.volSurfaces[surface.Pair] = surface
+
+	m.logger.Info("updated vol grid",
+		zap.String("pair", surface.Pair),
+		zap.Int("points", len(surface.Points)),
+		zap.Int("expiries", len(surface.Slices())),
+		zap.String("interpolation", surface.Interpolation),
+	)
+
+	return nil
+}
+
+// GetVolSurface retrieves a volatility surface for a currency pair in either
+// orientation, inverting the strikes of a stored grid when needed
 func (m *Manager) GetVolSurface(pair string) (VolSurface, error) {
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return VolSurface{}, err
+	}
+
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	surface, exists := m.volSurfaces[pair]
-	if !exists {
-		return VolSurface{}, fmt.Errorf("vol surface not found for pair %s", pair)
+	if surface, exists := m.volSurfaces[currencyPair.String()]; exists {
+		return surface, nil
+	}
+	if surface, exists := m.volSurfaces[currencyPair.Invert().String()]; exists {
+		return invertVolSurface(surface, currencyPair), nil
 	}
 
-	return surface, nil
+	return VolSurface{}, fmt.Errorf("vol surface not found for pair %s", pair)
+}
+
+// invertSpotRate expresses a spot rate in the opposite orientation; the
+// inverted bid is the reciprocal of the ask and vice versa
+func invertSpotRate(spot SpotRate, pair models.CurrencyPair) SpotRate {
+	spot.Pair = pair.String()
+	spot.Rate = 1 / spot.Rate
+	if spot.IsTwoWay() {
+		spot.Bid, spot.Ask = 1/spot.Ask, 1/spot.Bid
+	}
+	return spot
+}
+
+// invertVolSurface expresses a vol surface in the opposite orientation. The
+// lognormal vol of 1/S equals that of S, with strike K mapping to 1/K.
+func invertVolSurface(surface VolSurface, pair models.CurrencyPair) VolSurface {
+	surface.Pair = pair.String()
+	if len(surface.Points) > 0 {
+		points := make([]VolPoint, len(surface.Points))
+		for i, p := range surface.Points {
+			points[i] = VolPoint{Strike: 1 / p.Strike, Maturity: p.Maturity, Volatility: p.Volatility}
+		}
+		surface.Points = points
+	}
+	if len(surface.Fits) > 0 {
+		fits := make([]SmileFit, len(surface.Fits))
+		for i, fit := range surface.Fits {
+			fit.Inverted = !fit.Inverted
+			fits[i] = fit
+		}
+		surface.Fits = fits
+	}
+	return surface
 }
 
 // GetSnapshot creates a point-in-time snapshot of all market data
@@ -194,27 +488,55 @@ func (m *Manager) GetSnapshot() MarketSnapshot {
 		volSurfaces[k] = v
 	}
 
+	correlations := make(map[string]float64, len(m.correlations))
+	for k, v := range m.correlations {
+		correlations[k] = v
+	}
+
 	return MarketSnapshot{
 		SpotRates:      spotRates,
 		DiscountCurves: discountCurves,
 		VolSurfaces:    volSurfaces,
+		Correlations:   correlations,
 		SnapshotTime:   time.Now(),
 	}
 }
 
 // LoadSnapshot loads a complete market snapshot (replaces current state)
 func (m *Manager) LoadSnapshot(snapshot MarketSnapshot) {
+	// A snapshot is loaded whole, so arbitrage is only flagged
+	m.mu.RLock()
+	policy := m.arbitragePolicy
+	m.mu.RUnlock()
+	if policy != ArbitrageOff {
+		if err := snapshot.CheckArbitrage(); err != nil {
+			m.logger.Warn("snapshot vol surfaces failed arbitrage checks", zap.Error(err))
+		}
+	}
+	if matrix, err := NewCorrelationMatrix(snapshot.Correlations); err != nil {
+		m.logger.Warn("snapshot correlations are invalid", zap.Error(err))
+	} else if minEigen := matrix.MinEigenvalue(); minEigen < -psdTolerance {
+		m.logger.Warn("snapshot correlation matrix is indefinite", zap.Float64("min_eigenvalue", minEigen))
+	}
+
+	correlations := snapshot.Correlations
+	if correlations == nil {
+		correlations = make(map[string]float64)
+	}
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.spotRates = snapshot.SpotRates
 	m.discountCurves = snapshot.DiscountCurves
 	m.volSurfaces = snapshot.VolSurfaces
+	m.correlations = correlations
 
 	m.logger.Info("loaded market snapshot",
 		zap.Int("spot_rates", len(snapshot.SpotRates)),
 		zap.Int("discount_curves", len(snapshot.DiscountCurves)),
 		zap.Int("vol_surfaces", len(snapshot.VolSurfaces)),
+		zap.Int("correlations", len(correlations)),
 		zap.Time("snapshot_time", snapshot.SnapshotTime),
 	)
 }
@@ -228,5 +550,6 @@ func (m *Manager) Stats() map[string]int {
 		"spot_rates":      len(m.spotRates),
 		"discount_curves": len(m.discountCurves),
 		"vol_surfaces":    len(m.volSurfaces),
+		"correlations":    len(m.correlations),
 	}
 }
@@ -0,0 +1,73 @@
+package market
+
+import (
+	"math"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestSpotUpdatesReplaceTheInversePair(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateSpotRate("EUR/USD", 1.10); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UpdateSpotRate("USD/EUR", 0.80); err != nil {
+		t.Fatal(err)
+	}
+
+	eurusd, err := m.GetSpotRate("EUR/USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	usdeur, err := m.GetSpotRate("USD/EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usdeur.Rate != 0.80 || math.Abs(eurusd.Rate*usdeur.Rate-1) > 1e-12 {
+		t.Errorf("EUR/USD %g and USD/EUR %g disagree after the USD/EUR update", eurusd.Rate, usdeur.Rate)
+	}
+	if n := len(m.GetSnapshot().SpotRates); n != 1 {
+		t.Errorf("snapshot holds %d rates, want 1", n)
+	}
+}
+
+func TestStaleQuoteCheckCoversTheInversePair(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateSpotQuote("EUR/USD", SpotQuote{Bid: 1.0999, Ask: 1.1001, Source: "EBS", Sequence: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UpdateSpotQuote("USD/EUR", SpotQuote{Bid: 0.90, Ask: 0.91, Source: "EBS", Sequence: 4}); err == nil {
+		t.Error("an older quote on the inverse pair should be rejected")
+	}
+	if err := m.UpdateSpotQuote("USD/EUR", SpotQuote{Bid: 0.90, Ask: 0.91, Source: "EBS", Sequence: 6}); err != nil {
+		t.Fatalf("newer quote on the inverse pair: %v", err)
+	}
+
+	spot, err := m.GetSpotRate("EUR/USD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spot.Bid-1/0.91) > 1e-12 || math.Abs(spot.Ask-1/0.90) > 1e-12 {
+		t.Errorf("EUR/USD = %g/%g, want the inverted USD/EUR quote", spot.Bid, spot.Ask)
+	}
+}
+
+func TestVolUpdatesReplaceTheInversePair(t *testing.T) {
+	m := NewManager(zap.NewNop())
+	if err := m.UpdateVolSurface("EUR/USD", 0.10); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.UpdateVolSurface("USD/EUR", 0.12); err != nil {
+		t.Fatal(err)
+	}
+	for _, pair := range []string{"EUR/USD", "USD/EUR"} {
+		surface, err := m.GetVolSurface(pair)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if surface.FlatVol != 0.12 {
+			t.Errorf("%s vol = %g, want the latest 0.12", pair, surface.FlatVol)
+		}
+	}
+}
@@ -0,0 +1,561 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// DeltaConvention is how the deltas of a smile's wing quotes are measured
+type DeltaConvention int
+
+const (
+	// DeltaSpot is the Garman-Kohlhagen spot delta, DFf N(d1)
+	DeltaSpot DeltaConvention = iota
+	// DeltaForward is the forward delta, N(d1)
+	DeltaForward
+	// DeltaSpotPremiumAdjusted is the spot delta less the premium paid in
+	// the foreign currency, DFf (K/F) N(d2); the norm for pairs such as
+	// USD/JPY where the premium is paid in the base currency
+	DeltaSpotPremiumAdjusted
+	// DeltaForwardPremiumAdjusted is the premium-adjusted forward delta, (K/F) N(d2)
+	DeltaForwardPremiumAdjusted
+)
+
+func (d DeltaConvention) String() string {
+	switch d {
+	case DeltaSpot:
+		return "spot"
+	case DeltaForward:
+		return "forward"
+	case DeltaSpotPremiumAdjusted:
+		return "spot_pa"
+	case DeltaForwardPremiumAdjusted:
+		return "forward_pa"
+	}
+	return ""
+}
+
+// ParseDeltaConvention parses "spot", "forward", "spot_pa" or "forward_pa"
+func ParseDeltaConvention(s string) (DeltaConvention, error) {
+	for _, d := range []DeltaConvention{DeltaSpot, DeltaForward, DeltaSpotPremiumAdjusted, DeltaForwardPremiumAdjusted} {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown delta convention: %s", s)
+}
+
+func (d DeltaConvention) premiumAdjusted() bool {
+	return d == DeltaSpotPremiumAdjusted || d == DeltaForwardPremiumAdjusted
+}
+
+func (d DeltaConvention) spot() bool {
+	return d == DeltaSpot || d == DeltaSpotPremiumAdjusted
+}
+
+// ATMConvention selects the strike the ATM vol is quoted at
+type ATMConvention int
+
+const (
+	// ATMDeltaNeutral is the delta-neutral straddle strike, where call and
+	// put deltas cancel under the smile's delta convention
+	ATMDeltaNeutral ATMConvention = iota
+	// ATMForward is the outright forward
+	ATMForward
+)
+
+func (a ATMConvention) String() string {
+	switch a {
+	case ATMDeltaNeutral:
+		return "dns"
+	case ATMForward:
+		return "forward"
+	}
+	return ""
+}
+
+// ParseATMConvention parses "dns" or "forward"
+func ParseATMConvention(s string) (ATMConvention, error) {
+	for _, a := range []ATMConvention{ATMDeltaNeutral, ATMForward} {
+		if a.String() == s {
+			return a, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown ATM convention: %s", s)
+}
+
+// ButterflyConvention is how the butterflies of a smile's wing quotes are
+// measured
+type ButterflyConvention int
+
+const (
+	// ButterflySmile is the smile strangle: the wing vols are ATM + BF ± RR/2
+	ButterflySmile ButterflyConvention = iota
+	// ButterflyBroker is the broker (market) strangle: BF is the single vol
+	// spread over ATM at which a strangle struck at the wing delta is priced,
+	// and the smile must price the same strikes to the same value
+	ButterflyBroker
+)
+
+func (b ButterflyConvention) String() string {
+	switch b {
+	case ButterflySmile:
+		return "smile"
+	case ButterflyBroker:
+		return "broker"
+	}
+	return ""
+}
+
+// ParseButterflyConvention parses "smile" or "broker"
+func ParseButterflyConvention(s string) (ButterflyConvention, error) {
+	for _, b := range []ButterflyConvention{ButterflySmile, ButterflyBroker} {
+		if b.String() == s {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown butterfly convention: %s", s)
+}
+
+// SmileConventions are the quoting conventions of a pair's vol smiles
+type SmileConventions struct {
+	Delta     DeltaConvention
+	ATM       ATMConvention
+	Butterfly ButterflyConvention
+}
+
+// SmileQuote holds the broker quotes for one expiry, given by date or by
+// tenor from the surface's reference date
+type SmileQuote struct {
+	Maturity time.Time // Takes precedence over Tenor when set
+	Tenor    string    // e.g. "1M", "1Y"
+	ATM      float64
+	Wings    []WingQuote // e.g. 25 and 10 delta
+}
+
+// WingQuote is a risk reversal and butterfly at one delta. The butterfly is
+// read under the smile's ButterflyConvention; smile butterflies give call
+// and put vols of ATM + BF ± RR/2.
+type WingQuote struct {
+	Delta        float64 // absolute delta, e.g. 0.25
+	RiskReversal float64 // call vol minus put vol
+	Butterfly    float64 // smile: average wing vol minus ATM; broker: strangle vol minus ATM
+}
+
+// SmileMarket is the market a smile's deltas are measured in
+type SmileMarket struct {
+	ReferenceDate time.Time
+	Spot          float64       // quote currency per unit of base
+	Domestic      DiscountCurve // quote currency curve
+	Foreign       DiscountCurve // base currency curve
+}
+
+// BuildSmileSurface converts delta-quoted smiles into a strike grid. Each
+// expiry gives the ATM strike plus a put and a call strike per wing, with
+// cubic interpolation between them.
+func BuildSmileSurface(pair string, mkt SmileMarket, quotes []SmileQuote, conventions SmileConventions) (VolSurface, error) {
+	if mkt.ReferenceDate.IsZero() {
+		return VolSurface{}, fmt.Errorf("smile %s: reference date is unset", pair)
+	}
+	if mkt.Spot <= 0 || math.IsNaN(mkt.Spot) || math.IsInf(mkt.Spot, 0) {
+		return VolSurface{}, fmt.Errorf("smile %s: spot %f must be positive", pair, mkt.Spot)
+	}
+	if conventions.Delta.String() == "" {
+		return VolSurface{}, fmt.Errorf("smile %s: invalid delta convention %d", pair, int(conventions.Delta))
+	}
+	if conventions.ATM.String() == "" {
+		return VolSurface{}, fmt.Errorf("smile %s: invalid ATM convention %d", pair, int(conventions.ATM))
+	}
+	if conventions.Butterfly.String() == "" {
+		return VolSurface{}, fmt.Errorf("smile %s: invalid butterfly convention %d", pair, int(conventions.Butterfly))
+	}
+	if len(quotes) == 0 {
+		return VolSurface{}, fmt.Errorf("smile %s has no quotes", pair)
+	}
+
+	surface := VolSurface{
+		Pair:          pair,
+		ReferenceDate: mkt.ReferenceDate,
+		Interpolation: VolInterpCubic,
+	}
+	for i, quote := range quotes {
+		maturity := quote.Maturity
+		if maturity.IsZero() {
+			var err error
+			if maturity, err = addTenor(mkt.ReferenceDate, quote.Tenor); err != nil {
+				return VolSurface{}, fmt.Errorf("smile %s: quote %d: %w", pair, i, err)
+			}
+		}
+		points, err := buildSmile(mkt, maturity, quote, conventions)
+		if err != nil {
+			return VolSurface{}, fmt.Errorf("smile %s: %s: %w", pair, maturity.Format("2006-01-02"), err)
+		}
+		surface.Points = append(surface.Points, points...)
+	}
+
+	if err := surface.Validate(); err != nil {
+		return VolSurface{}, err
+	}
+	return surface, nil
+}
+
+// UpdateDeltaSmile builds a vol grid from delta-quoted smiles, using the
+// stored spot and the curves of the pair's two currencies, and stores it in
+// place of any surface stored for the inverse pair
+func (m *Manager) UpdateDeltaSmile(pair string, referenceDate time.Time, quotes []SmileQuote, conventions SmileConventions) error {
+	currencyPair, err := models.ParseCurrencyPair(pair)
+	if err != nil {
+		return err
+	}
+	spot, err := m.GetSpotRate(currencyPair.String())
+	if err != nil {
+		return err
+	}
+	domestic, err := m.GetDiscountCurve(currencyPair.Quote.String())
+	if err != nil {
+		return err
+	}
+	foreign, err := m.GetDiscountCurve(currencyPair.Base.String())
+	if err != nil {
+		return err
+	}
+
+	surface, err := BuildSmileSurface(currencyPair.String(), SmileMarket{
+		ReferenceDate: referenceDate,
+		Spot:          spot.Rate,
+		Domestic:      domestic,
+		Foreign:       foreign,
+	}, quotes, conventions)
+	if err != nil {
+		return err
+	}
+	if err := m.checkArbitrage(surface); err != nil {
+		return err
+	}
+	surface.Timestamp = time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.volSurfaces, currencyPair.Invert().String())
+	m.volSurfaces[surface.Pair] = surface
+
+	m.logger.Info("updated delta smile",
+		zap.String("pair", surface.Pair),
+		zap.Int("expiries", len(quotes)),
+		zap.String("delta", conventions.Delta.String()),
+		zap.String("atm", conventions.ATM.String()),
+		zap.String("butterfly", conventions.Butterfly.String()),
+	)
+
+	return nil
+}
+
+// buildSmile returns the strike points of one expiry, sorted by strike
+func buildSmile(mkt SmileMarket, maturity time.Time, quote SmileQuote, conventions SmileConventions) ([]VolPoint, error) {
+	t := DayCountAct365F.YearFraction(mkt.ReferenceDate, maturity)
+	if t <= 0 {
+		return nil, fmt.Errorf("maturity is not after the reference date")
+	}
+	if quote.ATM <= 0 || math.IsNaN(quote.ATM) {
+		return nil, fmt.Errorf("ATM vol %f must be positive", quote.ATM)
+	}
+
+	forward, dfForeign, err := mkt.forward(maturity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deltas are scaled to forward terms: spot deltas carry a DFf factor
+	scale := 1.0
+	if conventions.Delta.spot() {
+		scale = dfForeign
+	}
+	for _, wing := range quote.Wings {
+		if wing.Delta <= 0 || wing.Delta >= 0.5 {
+			return nil, fmt.Errorf("wing delta %f must be between 0 and 0.5", wing.Delta)
+		}
+		if wing.Delta/scale >= 1 {
+			return nil, fmt.Errorf("%g delta is unattainable with foreign discount factor %f", wing.Delta, dfForeign)
+		}
+	}
+
+	b := smileBuilder{
+		forward:     forward,
+		t:           t,
+		maturity:    maturity,
+		scale:       scale,
+		conventions: conventions,
+	}
+	wings := quote.Wings
+	if conventions.Butterfly == ButterflyBroker {
+		if wings, err = b.smileButterflies(quote.ATM, wings); err != nil {
+			return nil, err
+		}
+	}
+	return b.points(quote.ATM, wings)
+}
+
+// smileBuilder places the strikes of one expiry
+type smileBuilder struct {
+	forward     float64
+	t           float64
+	maturity    time.Time
+	scale       float64 // DFf for spot deltas, else 1
+	conventions SmileConventions
+}
+
+// strikes returns the put and call strikes at a wing's delta for the given
+// vols
+func (b smileBuilder) strikes(delta, putVol, callVol float64) (float64, float64, error) {
+	target := delta / b.scale
+	pa := b.conventions.Delta.premiumAdjusted()
+	callStrike, err := callStrikeFromDelta(b.forward, callVol, b.t, target, pa)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%g delta call: %w", delta, err)
+	}
+	return putStrikeFromDelta(b.forward, putVol, b.t, target, pa), callStrike, nil
+}
+
+// points returns the ATM point and a put and call point per smile-butterfly
+// wing, sorted by strike
+func (b smileBuilder) points(atm float64, wings []WingQuote) ([]VolPoint, error) {
+	points := []VolPoint{{
+		Strike:     atmStrike(b.forward, atm, b.t, b.conventions),
+		Maturity:   b.maturity,
+		Volatility: atm,
+	}}
+	for _, wing := range wings {
+		callVol := atm + wing.Butterfly + wing.RiskReversal/2
+		putVol := atm + wing.Butterfly - wing.RiskReversal/2
+		if callVol <= 0 || putVol <= 0 {
+			return nil, fmt.Errorf("%g delta wing gives non-positive vols: call %f, put %f", wing.Delta, callVol, putVol)
+		}
+		putStrike, callStrike, err := b.strikes(wing.Delta, putVol, callVol)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points,
+			VolPoint{Strike: putStrike, Maturity: b.maturity, Volatility: putVol},
+			VolPoint{Strike: callStrike, Maturity: b.maturity, Volatility: callVol},
+		)
+	}
+
+	sort.Slice(points, func(i, j int) bool { return points[i].Strike < points[j].Strike })
+	for i := 1; i < len(points); i++ {
+		if points[i].Strike <= points[i-1].Strike*(1+1e-12) {
+			return nil, fmt.Errorf("quotes give overlapping strikes %f and %f", points[i-1].Strike, points[i].Strike)
+		}
+	}
+	return points, nil
+}
+
+// smileButterflies converts broker butterflies to smile butterflies. Each
+// broker strangle is struck at its wing delta with the single vol ATM + BF;
+// the smile butterfly of the wing is solved by Newton steps so that the
+// cubic smile prices those strikes to the broker strangle's value. Wings are
+// solved in turn, since each moves the spline under the others, until every
+// strangle reprices.
+func (b smileBuilder) smileButterflies(atm float64, wings []WingQuote) ([]WingQuote, error) {
+	type strangle struct {
+		put, call, value float64
+	}
+	strangles := make([]strangle, len(wings))
+	for i, wing := range wings {
+		vol := atm + wing.Butterfly
+		if vol <= 0 {
+			return nil, fmt.Errorf("%g delta broker strangle vol %f must be positive", wing.Delta, vol)
+		}
+		put, call, err := b.strikes(wing.Delta, vol, vol)
+		if err != nil {
+			return nil, err
+		}
+		value := blackPrice(b.forward, put, vol, b.t, false) + blackPrice(b.forward, call, vol, b.t, true)
+		strangles[i] = strangle{put: put, call: call, value: value}
+	}
+
+	smile := append([]WingQuote(nil), wings...)
+	// value prices strangle i on the smile built from the current butterflies
+	value := func(i int) (float64, error) {
+		points, err := b.points(atm, smile)
+		if err != nil {
+			return 0, err
+		}
+		slice := volSlice{}
+		for _, point := range points {
+			slice.strikes = append(slice.strikes, point.Strike)
+			slice.vols = append(slice.vols, point.Volatility)
+		}
+		slice.curves = naturalSplineCurvatures(slice.strikes, slice.vols)
+		s := strangles[i]
+		return blackPrice(b.forward, s.put, slice.vol(s.put), b.t, false) +
+			blackPrice(b.forward, s.call, slice.vol(s.call), b.t, true), nil
+	}
+
+	const bump = 1e-6
+	tolerance := 1e-12 * b.forward
+	for sweep := 0; sweep < 50; sweep++ {
+		converged := true
+		for i := range smile {
+			for step := 0; step < 50; step++ {
+				v, err := value(i)
+				if err != nil {
+					return nil, fmt.Errorf("%g delta broker butterfly: %w", wings[i].Delta, err)
+				}
+				diff := v - strangles[i].value
+				if math.Abs(diff) <= tolerance {
+					break
+				}
+				converged = false
+
+				smile[i].Butterfly += bump
+				bumped, err := value(i)
+				smile[i].Butterfly -= bump
+				if err != nil {
+					return nil, fmt.Errorf("%g delta broker butterfly: %w", wings[i].Delta, err)
+				}
+				slope := (bumped - v) / bump
+				if slope <= 0 {
+					return nil, fmt.Errorf("%g delta broker butterfly: strangle value does not rise with the smile butterfly", wings[i].Delta)
+				}
+				smile[i].Butterfly -= diff / slope
+			}
+		}
+		if converged {
+			return smile, nil
+		}
+	}
+	return nil, fmt.Errorf("broker butterflies did not converge to smile butterflies")
+}
+
+// blackPrice is the undiscounted Black price of a call or put on the forward
+func blackPrice(forward, strike, vol, t float64, call bool) float64 {
+	sd := vol * math.Sqrt(t)
+	d1 := (math.Log(forward/strike) + sd*sd/2) / sd
+	d2 := d1 - sd
+	if call {
+		return forward*normalCDF(d1) - strike*normalCDF(d2)
+	}
+	return strike*normalCDF(-d2) - forward*normalCDF(-d1)
+}
+
+// forward returns the outright forward to maturity and the foreign
+// discount factor
+func (mkt SmileMarket) forward(maturity time.Time) (float64, float64, error) {
+	dfDomestic, err := discountFactorOn(mkt.Domestic, mkt.ReferenceDate, maturity)
+	if err != nil {
+		return 0, 0, err
+	}
+	dfForeign, err := discountFactorOn(mkt.Foreign, mkt.ReferenceDate, maturity)
+	if err != nil {
+		return 0, 0, err
+	}
+	return mkt.Spot * dfForeign / dfDomestic, dfForeign, nil
+}
+
+// discountFactorOn reads a curve's discount factor to maturity. Flat curves
+// have no reference date of their own and are read from the smile's.
+func discountFactorOn(curve DiscountCurve, referenceDate, maturity time.Time) (float64, error) {
+	if curve.ReferenceDate.IsZero() {
+		curve.ReferenceDate = referenceDate
+	}
+	return curve.DiscountFactor(curve.YearFraction(maturity))
+}
+
+// atmStrike returns the ATM strike. The delta-neutral straddle strike is
+// F exp(σ²t/2), or F exp(-σ²t/2) when deltas are premium adjusted.
+func atmStrike(forward, vol, t float64, conventions SmileConventions) float64 {
+	if conventions.ATM == ATMForward {
+		return forward
+	}
+	if conventions.Delta.premiumAdjusted() {
+		return forward * math.Exp(-vol*vol*t/2)
+	}
+	return forward * math.Exp(vol*vol*t/2)
+}
+
+// callStrikeFromDelta solves for the call strike with forward delta target.
+// The premium-adjusted delta (K/F) N(d2) is not monotone in K: it rises from
+// zero to a maximum and falls back, and by convention the strike above the
+// maximum is taken.
+func callStrikeFromDelta(forward, vol, t, target float64, pa bool) (float64, error) {
+	sd := vol * math.Sqrt(t)
+	// Unadjusted: N(d1) = target
+	strike := forward * math.Exp(-sd*inverseNormalCDF(target)+sd*sd/2)
+	if !pa {
+		return strike, nil
+	}
+
+	// The adjusted delta peaks where sd N(d2) = n(d2), for d2 > -sd
+	d2Max := bisect(func(d float64) float64 {
+		return sd*normalCDF(d) - normalPDF(d)
+	}, -sd, 10)
+	strikeMax := forward * math.Exp(-sd*d2Max-sd*sd/2)
+	paDelta := func(k float64) float64 {
+		d2 := (math.Log(forward/k) - sd*sd/2) / sd
+		return k / forward * normalCDF(d2)
+	}
+	if paDelta(strikeMax) < target {
+		return 0, fmt.Errorf("premium-adjusted delta %f is unattainable", target)
+	}
+
+	// The adjusted delta is below N(d1), so the root lies under the
+	// unadjusted strike
+	logK := bisect(func(x float64) float64 {
+		return paDelta(math.Exp(x)) - target
+	}, math.Log(strikeMax), math.Log(strike))
+	return math.Exp(logK), nil
+}
+
+// putStrikeFromDelta solves for the put strike with absolute forward delta
+// target. The premium-adjusted delta (K/F) N(-d2) rises with K.
+func putStrikeFromDelta(forward, vol, t, target float64, pa bool) float64 {
+	sd := vol * math.Sqrt(t)
+	// Unadjusted: N(-d1) = target
+	strike := forward * math.Exp(sd*inverseNormalCDF(target)+sd*sd/2)
+	if !pa {
+		return strike
+	}
+
+	// The adjusted delta exceeds N(-d1), so the root lies under the
+	// unadjusted strike
+	logK := bisect(func(x float64) float64 {
+		k := math.Exp(x)
+		d2 := (math.Log(forward/k) - sd*sd/2) / sd
+		return k/forward*normalCDF(-d2) - target
+	}, math.Log(strike)-10*sd, math.Log(strike))
+	return math.Exp(logK)
+}
+
+// bisect finds a root of f in [lo, hi], where f changes sign
+func bisect(f func(float64) float64, lo, hi float64) float64 {
+	fLo := f(lo)
+	for i := 0; i < 200 && hi-lo > 1e-14; i++ {
+		mid := (lo + hi) / 2
+		fMid := f(mid)
+		if (fMid < 0) == (fLo < 0) {
+			lo, fLo = mid, fMid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normalPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
+
+func inverseNormalCDF(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
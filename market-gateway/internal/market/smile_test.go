@@ -0,0 +1,196 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func testSmileMarket() SmileMarket {
+	reference := date(2026, time.January, 2)
+	return SmileMarket{
+		ReferenceDate: reference,
+		Spot:          150,
+		Domestic:      DiscountCurve{Currency: "JPY", FlatRate: 0.005, Compounding: CompoundContinuous},
+		Foreign:       DiscountCurve{Currency: "USD", FlatRate: 0.045, Compounding: CompoundContinuous},
+	}
+}
+
+// optionDelta is the delta of a call or put under a convention, with puts
+// negative
+func optionDelta(forward, dfForeign, strike, vol, t float64, call bool, convention DeltaConvention) float64 {
+	sd := vol * math.Sqrt(t)
+	d1 := (math.Log(forward/strike) + sd*sd/2) / sd
+	d2 := d1 - sd
+
+	var delta float64
+	switch {
+	case convention.premiumAdjusted() && call:
+		delta = strike / forward * normalCDF(d2)
+	case convention.premiumAdjusted():
+		delta = -strike / forward * normalCDF(-d2)
+	case call:
+		delta = normalCDF(d1)
+	default:
+		delta = normalCDF(d1) - 1
+	}
+	if convention.spot() {
+		delta *= dfForeign
+	}
+	return delta
+}
+
+func TestDeltaStrikeRoundTrips(t *testing.T) {
+	mkt := testSmileMarket()
+	maturity := date(2027, time.January, 4)
+	tau := DayCountAct365F.YearFraction(mkt.ReferenceDate, maturity)
+	forward, dfForeign, err := mkt.forward(maturity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quote := SmileQuote{
+		Maturity: maturity,
+		ATM:      0.10,
+		Wings: []WingQuote{
+			{Delta: 0.25, RiskReversal: -0.015, Butterfly: 0.004},
+			{Delta: 0.10, RiskReversal: -0.030, Butterfly: 0.012},
+		},
+	}
+	for _, convention := range []DeltaConvention{DeltaSpot, DeltaForward, DeltaSpotPremiumAdjusted, DeltaForwardPremiumAdjusted} {
+		for _, atm := range []ATMConvention{ATMDeltaNeutral, ATMForward} {
+			conventions := SmileConventions{Delta: convention, ATM: atm}
+			points, err := buildSmile(mkt, maturity, quote, conventions)
+			if err != nil {
+				t.Fatalf("%s/%s: buildSmile: %v", convention, atm, err)
+			}
+			if len(points) != 5 {
+				t.Fatalf("%s/%s: got %d points, want 5", convention, atm, len(points))
+			}
+
+			// Sorted by strike: 10d put, 25d put, ATM, 25d call, 10d call
+			wings := []struct {
+				point int
+				call  bool
+				delta float64
+			}{{0, false, -0.10}, {1, false, -0.25}, {3, true, 0.25}, {4, true, 0.10}}
+			for _, w := range wings {
+				p := points[w.point]
+				got := optionDelta(forward, dfForeign, p.Strike, p.Volatility, tau, w.call, convention)
+				if math.Abs(got-w.delta) > 1e-10 {
+					t.Errorf("%s/%s: delta at strike %g = %.12f, want %g", convention, atm, p.Strike, got, w.delta)
+				}
+			}
+
+			atmPoint := points[2]
+			if atmPoint.Volatility != quote.ATM {
+				t.Fatalf("%s/%s: middle point has vol %g, want the ATM vol", convention, atm, atmPoint.Volatility)
+			}
+			if atm == ATMForward {
+				if math.Abs(atmPoint.Strike-forward) > 1e-12 {
+					t.Errorf("%s/forward: ATM strike %g, want the forward %g", convention, atmPoint.Strike, forward)
+				}
+				continue
+			}
+			// The delta-neutral straddle's call and put deltas cancel
+			call := optionDelta(forward, dfForeign, atmPoint.Strike, quote.ATM, tau, true, convention)
+			put := optionDelta(forward, dfForeign, atmPoint.Strike, quote.ATM, tau, false, convention)
+			if math.Abs(call+put) > 1e-12 {
+				t.Errorf("%s/dns: straddle delta at %g = %g, want 0", convention, atmPoint.Strike, call+put)
+			}
+		}
+	}
+}
+
+func TestPremiumAdjustedCallStrikeIsAboveDeltaPeak(t *testing.T) {
+	forward, vol, tau := 1.0, 0.8, 4.0
+	strike, err := callStrikeFromDelta(forward, vol, tau, 0.10, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Above the peak the adjusted delta falls as the strike rises
+	bump := strike * 1.001
+	sd := vol * math.Sqrt(tau)
+	paDelta := func(k float64) float64 {
+		return k / forward * normalCDF((math.Log(forward/k)-sd*sd/2)/sd)
+	}
+	if paDelta(bump) >= paDelta(strike) {
+		t.Errorf("strike %g is below the premium-adjusted delta peak", strike)
+	}
+
+	if _, err := callStrikeFromDelta(forward, vol, tau, 0.45, true); err == nil {
+		t.Error("a delta above the premium-adjusted peak should be unattainable")
+	}
+}
+
+func TestBrokerButterfliesRepriceStrangles(t *testing.T) {
+	mkt := testSmileMarket()
+	maturity := date(2027, time.January, 4)
+	tau := DayCountAct365F.YearFraction(mkt.ReferenceDate, maturity)
+	forward, dfForeign, err := mkt.forward(maturity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quote := SmileQuote{
+		Maturity: maturity,
+		ATM:      0.10,
+		Wings: []WingQuote{
+			{Delta: 0.25, RiskReversal: -0.015, Butterfly: 0.004},
+			{Delta: 0.10, RiskReversal: -0.030, Butterfly: 0.012},
+		},
+	}
+	for _, convention := range []DeltaConvention{DeltaSpot, DeltaForwardPremiumAdjusted} {
+		conventions := SmileConventions{Delta: convention, Butterfly: ButterflyBroker}
+		surface, err := BuildSmileSurface("USD/JPY", mkt, []SmileQuote{quote}, conventions)
+		if err != nil {
+			t.Fatalf("%s: BuildSmileSurface: %v", convention, err)
+		}
+
+		smile, err := BuildSmileSurface("USD/JPY", mkt, []SmileQuote{quote}, SmileConventions{Delta: convention})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if surface.Points[0].Volatility == smile.Points[0].Volatility {
+			t.Errorf("%s: broker and smile butterflies give the same wing vols", convention)
+		}
+
+		b := smileBuilder{forward: forward, t: tau, maturity: maturity, scale: 1, conventions: conventions}
+		if convention.spot() {
+			b.scale = dfForeign
+		}
+		for _, wing := range quote.Wings {
+			vol := quote.ATM + wing.Butterfly
+			put, call, err := b.strikes(wing.Delta, vol, vol)
+			if err != nil {
+				t.Fatal(err)
+			}
+			putVol, err := surface.Vol(put, maturity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			callVol, err := surface.Vol(call, maturity)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := blackPrice(forward, put, vol, tau, false) + blackPrice(forward, call, vol, tau, true)
+			got := blackPrice(forward, put, putVol, tau, false) + blackPrice(forward, call, callVol, tau, true)
+			if math.Abs(got-want) > 1e-9*forward {
+				t.Errorf("%s: %g delta broker strangle on the smile = %g, want %g", convention, wing.Delta, got, want)
+			}
+		}
+	}
+}
+
+func TestParseButterflyConvention(t *testing.T) {
+	for _, b := range []ButterflyConvention{ButterflySmile, ButterflyBroker} {
+		got, err := ParseButterflyConvention(b.String())
+		if err != nil || got != b {
+			t.Errorf("ParseButterflyConvention(%q) = %v, %v", b.String(), got, err)
+		}
+	}
+	if _, err := ParseButterflyConvention("market"); err == nil {
+		t.Error("unknown butterfly convention should fail")
+	}
+}
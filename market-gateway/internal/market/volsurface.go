@@ -0,0 +1,240 @@
+package market
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Strike interpolation methods for vol grids. Between expiries every method
+// is linear in total variance σ²t at a fixed strike; outside the grid vols
+// are held flat in both strike and maturity. See also VolInterpSABR and
+// VolInterpSVI.
+const (
+	// VolInterpLinear is linear in vol between strikes
+	VolInterpLinear = "linear"
+	// VolInterpCubic is a natural cubic spline in vol between strikes
+	VolInterpCubic = "cubic"
+)
+
+// VolTime returns the ACT/365F time to maturity from the surface's reference
+// date, matching yearFrac on the pricing side
+func (s VolSurface) VolTime(maturity time.Time) float64 {
+	return DayCountAct365F.YearFraction(s.ReferenceDate, maturity)
+}
+
+// Vol returns the volatility at a strike and maturity
+func (s VolSurface) Vol(strike float64, maturity time.Time) (float64, error) {
+	if !s.IsGrid() {
+		return s.FlatVol, nil
+	}
+	if strike <= 0 || math.IsNaN(strike) {
+		return 0, fmt.Errorf("vol surface %s: strike %f must be positive", s.Pair, strike)
+	}
+	grid, err := s.grid()
+	if err != nil {
+		return 0, err
+	}
+	return grid.vol(strike, s.VolTime(maturity)), nil
+}
+
+// IsGrid reports whether the surface has grid points or smile fits rather
+// than a flat vol
+func (s VolSurface) IsGrid() bool {
+	return len(s.Points) > 0 || len(s.Fits) > 0
+}
+
+// Validate checks that the surface can be evaluated
+func (s VolSurface) Validate() error {
+	if !s.IsGrid() {
+		if s.FlatVol < 0 || s.FlatVol > 1 {
+			return fmt.Errorf("vol surface %s: flat volatility %f must be between 0 and 1", s.Pair, s.FlatVol)
+		}
+		return nil
+	}
+	_, err := s.grid()
+	return err
+}
+
+// Slices returns the grid points grouped by maturity, each slice sorted by
+// strike, in maturity order
+func (s VolSurface) Slices() [][]VolPoint {
+	points := append([]VolPoint(nil), s.Points...)
+	sort.SliceStable(points, func(i, j int) bool {
+		if !points[i].Maturity.Equal(points[j].Maturity) {
+			return points[i].Maturity.Before(points[j].Maturity)
+		}
+		return points[i].Strike < points[j].Strike
+	})
+
+	var slices [][]VolPoint
+	for _, point := range points {
+		n := len(slices)
+		if n > 0 && slices[n-1][0].Maturity.Equal(point.Maturity) {
+			slices[n-1] = append(slices[n-1], point)
+			continue
+		}
+		slices = append(slices, []VolPoint{point})
+	}
+	return slices
+}
+
+// volSlice is the smile at one expiry
+type volSlice struct {
+	t        float64
+	maturity time.Time
+	strikes  []float64 // strictly increasing
+	vols     []float64
+	curves   []float64 // cubic only: spline second derivatives
+	fit      *SmileFit // SABR and SVI only
+}
+
+// volGrid evaluates a grid surface
+type volGrid struct {
+	method string
+	slices []volSlice // strictly increasing t
+}
+
+func (s VolSurface) grid() (*volGrid, error) {
+	if s.ReferenceDate.IsZero() {
+		return nil, fmt.Errorf("vol surface %s: grids need a reference date", s.Pair)
+	}
+	grid := &volGrid{}
+	switch s.Interpolation {
+	case VolInterpLinear, "":
+		grid.method = VolInterpLinear
+	case VolInterpCubic:
+		grid.method = VolInterpCubic
+	case VolInterpSABR, VolInterpSVI:
+		return s.fitGrid()
+	default:
+		return nil, fmt.Errorf("vol surface %s: unknown interpolation %q", s.Pair, s.Interpolation)
+	}
+
+	for _, points := range s.Slices() {
+		maturity := points[0].Maturity.Format("2006-01-02")
+		slice := volSlice{t: s.VolTime(points[0].Maturity), maturity: points[0].Maturity}
+		if slice.t <= 0 {
+			return nil, fmt.Errorf("vol surface %s: maturity %s is not after the reference date", s.Pair, maturity)
+		}
+		for i, point := range points {
+			if point.Strike <= 0 || math.IsNaN(point.Strike) || math.IsInf(point.Strike, 0) {
+				return nil, fmt.Errorf("vol surface %s: strike %f at %s must be positive", s.Pair, point.Strike, maturity)
+			}
+			if i > 0 && point.Strike == points[i-1].Strike {
+				return nil, fmt.Errorf("vol surface %s: duplicate strike %f at %s", s.Pair, point.Strike, maturity)
+			}
+			if point.Volatility <= 0 || math.IsNaN(point.Volatility) || math.IsInf(point.Volatility, 0) {
+				return nil, fmt.Errorf("vol surface %s: volatility %f at strike %f, %s must be positive",
+					s.Pair, point.Volatility, point.Strike, maturity)
+			}
+			slice.strikes = append(slice.strikes, point.Strike)
+			slice.vols = append(slice.vols, point.Volatility)
+		}
+		if n := len(grid.slices); n > 0 && slice.t == grid.slices[n-1].t {
+			return nil, fmt.Errorf("vol surface %s: duplicate maturity %s", s.Pair, maturity)
+		}
+		if grid.method == VolInterpCubic {
+			slice.curves = naturalSplineCurvatures(slice.strikes, slice.vols)
+		}
+		grid.slices = append(grid.slices, slice)
+	}
+	return grid, nil
+}
+
+// fitGrid builds the grid of a calibrated surface from its fits
+func (s VolSurface) fitGrid() (*volGrid, error) {
+	if len(s.Fits) == 0 {
+		return nil, fmt.Errorf("vol surface %s: %s interpolation needs calibrated fits", s.Pair, s.Interpolation)
+	}
+	fits := append([]SmileFit(nil), s.Fits...)
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].Maturity.Before(fits[j].Maturity) })
+
+	grid := &volGrid{method: s.Interpolation}
+	for i := range fits {
+		maturity := fits[i].Maturity.Format("2006-01-02")
+		if err := fits[i].validate(s.Interpolation); err != nil {
+			return nil, fmt.Errorf("vol surface %s: fit %s: %w", s.Pair, maturity, err)
+		}
+		slice := volSlice{t: s.VolTime(fits[i].Maturity), maturity: fits[i].Maturity, fit: &fits[i]}
+		if slice.t <= 0 {
+			return nil, fmt.Errorf("vol surface %s: maturity %s is not after the reference date", s.Pair, maturity)
+		}
+		if n := len(grid.slices); n > 0 && slice.t == grid.slices[n-1].t {
+			return nil, fmt.Errorf("vol surface %s: duplicate maturity %s", s.Pair, maturity)
+		}
+		grid.slices = append(grid.slices, slice)
+	}
+	return grid, nil
+}
+
+// vol interpolates linearly in total variance between the expiries around t
+func (g *volGrid) vol(strike, t float64) float64 {
+	n := len(g.slices)
+	switch {
+	case t <= g.slices[0].t:
+		return g.slices[0].vol(strike)
+	case t >= g.slices[n-1].t:
+		return g.slices[n-1].vol(strike)
+	}
+
+	k := sort.Search(n, func(i int) bool { return g.slices[i].t >= t }) - 1
+	lo, hi := g.slices[k], g.slices[k+1]
+	vLo, vHi := lo.vol(strike), hi.vol(strike)
+	wLo, wHi := vLo*vLo*lo.t, vHi*vHi*hi.t
+	w := wLo + (t-lo.t)/(hi.t-lo.t)*(wHi-wLo)
+	return math.Sqrt(w / t)
+}
+
+// vol interpolates the smile in strike, flat outside the quoted strikes.
+// Fitted smiles are evaluated from their model instead.
+func (s volSlice) vol(strike float64) float64 {
+	if s.fit != nil {
+		return s.fit.Vol(strike, s.t)
+	}
+	n := len(s.strikes)
+	switch {
+	case strike <= s.strikes[0]:
+		return s.vols[0]
+	case strike >= s.strikes[n-1]:
+		return s.vols[n-1]
+	}
+
+	k := sort.SearchFloat64s(s.strikes, strike) - 1
+	x0, x1 := s.strikes[k], s.strikes[k+1]
+	y0, y1 := s.vols[k], s.vols[k+1]
+	h := x1 - x0
+	a := (x1 - strike) / h
+	b := (strike - x0) / h
+	v := a*y0 + b*y1
+	if s.curves != nil {
+		v += ((a*a*a-a)*s.curves[k] + (b*b*b-b)*s.curves[k+1]) * h * h / 6
+	}
+	return v
+}
+
+// naturalSplineCurvatures returns the second derivatives of the natural
+// cubic spline through the points, zero at both ends
+func naturalSplineCurvatures(xs, ys []float64) []float64 {
+	n := len(xs)
+	curves := make([]float64, n)
+	if n < 3 {
+		return curves
+	}
+
+	// Tridiagonal solve for the interior second derivatives
+	u := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		sig := (xs[i] - xs[i-1]) / (xs[i+1] - xs[i-1])
+		p := sig*curves[i-1] + 2
+		curves[i] = (sig - 1) / p
+		slope := (ys[i+1]-ys[i])/(xs[i+1]-xs[i]) - (ys[i]-ys[i-1])/(xs[i]-xs[i-1])
+		u[i] = (6*slope/(xs[i+1]-xs[i-1]) - sig*u[i-1]) / p
+	}
+	curves[n-1] = 0
+	for i := n - 2; i >= 0; i-- {
+		curves[i] = curves[i]*curves[i+1] + u[i]
+	}
+	return curves
+}
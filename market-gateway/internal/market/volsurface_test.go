@@ -0,0 +1,126 @@
+package market
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// twoExpiryGrid has a skewed smile at one and two years
+func twoExpiryGrid(interpolation string) VolSurface {
+	reference := date(2026, time.January, 2)
+	t1, t2 := date(2027, time.January, 4), date(2028, time.January, 3)
+	return VolSurface{
+		Pair:          "EUR/USD",
+		ReferenceDate: reference,
+		Interpolation: interpolation,
+		Points: []VolPoint{
+			{Strike: 1.0, Maturity: t1, Volatility: 0.12},
+			{Strike: 1.1, Maturity: t1, Volatility: 0.10},
+			{Strike: 1.2, Maturity: t1, Volatility: 0.11},
+			{Strike: 1.3, Maturity: t1, Volatility: 0.13},
+			{Strike: 1.0, Maturity: t2, Volatility: 0.13},
+			{Strike: 1.1, Maturity: t2, Volatility: 0.11},
+			{Strike: 1.2, Maturity: t2, Volatility: 0.12},
+			{Strike: 1.3, Maturity: t2, Volatility: 0.14},
+		},
+	}
+}
+
+func mustVol(t *testing.T, s VolSurface, strike float64, maturity time.Time) float64 {
+	t.Helper()
+	vol, err := s.Vol(strike, maturity)
+	if err != nil {
+		t.Fatalf("Vol(%g, %s): %v", strike, maturity.Format("2006-01-02"), err)
+	}
+	return vol
+}
+
+func TestVolGridPassesThroughPoints(t *testing.T) {
+	for _, interpolation := range []string{VolInterpLinear, VolInterpCubic} {
+		s := twoExpiryGrid(interpolation)
+		for _, p := range s.Points {
+			if got := mustVol(t, s, p.Strike, p.Maturity); math.Abs(got-p.Volatility) > 1e-12 {
+				t.Errorf("%s: vol at %g, %s = %g, want %g",
+					interpolation, p.Strike, p.Maturity.Format("2006-01-02"), got, p.Volatility)
+			}
+		}
+	}
+}
+
+func TestVolGridLinearInStrike(t *testing.T) {
+	s := twoExpiryGrid(VolInterpLinear)
+	maturity := s.Points[0].Maturity
+	// A quarter of the way from 1.1 (10%) to 1.2 (11%)
+	if got, want := mustVol(t, s, 1.125, maturity), 0.1025; math.Abs(got-want) > 1e-12 {
+		t.Errorf("vol at 1.125 = %g, want %g", got, want)
+	}
+}
+
+func TestVolGridNaturalSplineEnds(t *testing.T) {
+	s := twoExpiryGrid(VolInterpCubic)
+	grid, err := s.grid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, slice := range grid.slices {
+		n := len(slice.curves)
+		if slice.curves[0] != 0 || slice.curves[n-1] != 0 {
+			t.Errorf("%s: end curvatures %g, %g, want 0",
+				slice.maturity.Format("2006-01-02"), slice.curves[0], slice.curves[n-1])
+		}
+		// The spline bends away from the linear interpolant between strikes
+		mid := (slice.strikes[1] + slice.strikes[2]) / 2
+		linear := (slice.vols[1] + slice.vols[2]) / 2
+		if math.Abs(slice.vol(mid)-linear) < 1e-6 {
+			t.Errorf("%s: cubic vol at %g matches the linear vol", slice.maturity.Format("2006-01-02"), mid)
+		}
+	}
+}
+
+func TestVolGridLinearInTotalVariance(t *testing.T) {
+	for _, interpolation := range []string{VolInterpLinear, VolInterpCubic} {
+		s := twoExpiryGrid(interpolation)
+		t1, t2 := s.Points[0].Maturity, s.Points[4].Maturity
+		for _, strike := range []float64{0.9, 1.05, 1.15, 1.3, 1.5} {
+			v1, v2 := mustVol(t, s, strike, t1), mustVol(t, s, strike, t2)
+			w1, w2 := v1*v1*s.VolTime(t1), v2*v2*s.VolTime(t2)
+
+			for _, maturity := range []time.Time{date(2027, time.April, 1), date(2027, time.July, 1), date(2027, time.October, 1)} {
+				tau := s.VolTime(maturity)
+				vol := mustVol(t, s, strike, maturity)
+				want := w1 + (tau-s.VolTime(t1))/(s.VolTime(t2)-s.VolTime(t1))*(w2-w1)
+				if got := vol * vol * tau; math.Abs(got-want) > 1e-12 {
+					t.Errorf("%s: total variance at %g, %s = %g, want %g",
+						interpolation, strike, maturity.Format("2006-01-02"), got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestVolGridFlatExtrapolation(t *testing.T) {
+	for _, interpolation := range []string{VolInterpLinear, VolInterpCubic} {
+		s := twoExpiryGrid(interpolation)
+		t1, t2 := s.Points[0].Maturity, s.Points[4].Maturity
+
+		tests := []struct {
+			name     string
+			strike   float64
+			maturity time.Time
+			want     float64
+		}{
+			{"below strikes", 0.8, t1, 0.12},
+			{"above strikes", 1.6, t2, 0.14},
+			{"before first expiry", 1.1, date(2026, time.March, 2), 0.10},
+			{"after last expiry", 1.2, date(2035, time.January, 2), 0.12},
+			{"before first expiry, below strikes", 0.5, date(2026, time.February, 2), 0.12},
+			{"after last expiry, above strikes", 2.0, date(2040, time.January, 2), 0.14},
+		}
+		for _, tt := range tests {
+			if got := mustVol(t, s, tt.strike, tt.maturity); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("%s: %s: vol = %g, want %g", interpolation, tt.name, got, tt.want)
+			}
+		}
+	}
+}
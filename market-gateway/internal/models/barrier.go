@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Barrier option builders mirroring knockInOption and knockOutOption in
+// src/FX/Algebra/Combinators.hs. The barrier watches the spot rate of the
+// option's currency pair.
+
+// NewKnockInOption creates an option that is only acquired once spot crosses the barrier
+func NewKnockInOption(direction Direction, level float64, optType OptionType, strike float64, maturity time.Time, domestic, foreign Currency) (When, error) {
+	if err := validateBarrier(direction, level, optType, strike); err != nil {
+		return When{}, err
+	}
+
+	spotObs := NewSpotRate(domestic, foreign)
+	barrierHit := NewBarrier(direction, level, spotObs)
+	return NewWhen(barrierHit, NewEurOption(optType, strike, maturity, domestic, foreign)), nil
+}
+
+// NewKnockOutOption creates an option that becomes worthless once spot crosses the barrier.
+// It is expressed as the vanilla option minus the equivalent knock-in.
+func NewKnockOutOption(direction Direction, level float64, optType OptionType, strike float64, maturity time.Time, domestic, foreign Currency) (Combine, error) {
+	knockIn, err := NewKnockInOption(direction, level, optType, strike, maturity, domestic, foreign)
+	if err != nil {
+		return Combine{}, err
+	}
+
+	vanilla := NewEurOption(optType, strike, maturity, domestic, foreign)
+	return NewCombine(vanilla, NewScale(-1, knockIn)), nil
+}
+
+// NewUpAndInOption creates an option activated when spot rises through the barrier
+func NewUpAndInOption(level float64, optType OptionType, strike float64, maturity time.Time, domestic, foreign Currency) (When, error) {
+	return NewKnockInOption(Up, level, optType, strike, maturity, domestic, foreign)
+}
+
+// NewUpAndOutOption creates an option extinguished when spot rises through the barrier
+func NewUpAndOutOption(level float64, optType OptionType, strike float64, maturity time.Time, domestic, foreign Currency) (Combine, error) {
+	return NewKnockOutOption(Up, level, optType, strike, maturity, domestic, foreign)
+}
+
+// NewDownAndInOption creates an option activated when spot falls through the barrier
+func NewDownAndInOption(level float64, optType OptionType, strike float64, maturity time.Time, domestic, foreign Currency) (When, error) {
+	return NewKnockInOption(Down, level, optType, strike, maturity, domestic, foreign)
+}
+
+// NewDownAndOutOption creates an option extinguished when spot falls through the barrier
+func NewDownAndOutOption(level float64, optType OptionType, strike float64, maturity time.Time, domestic, foreign Currency) (Combine, error) {
+	return NewKnockOutOption(Down, level, optType, strike, maturity, domestic, foreign)
+}
+
+// validateBarrier checks the barrier level against the strike. An up barrier
+// on a call must sit above the strike and a down barrier on a put below it:
+// otherwise every in-the-money path has already crossed the barrier, so the
+// knock-in is just the vanilla option and the knock-out is worthless.
+func validateBarrier(direction Direction, level float64, optType OptionType, strike float64) error {
+	if level <= 0 {
+		return fmt.Errorf("invalid barrier level %f: must be positive", level)
+	}
+
+	if strike <= 0 {
+		return fmt.Errorf("invalid strike %f: must be positive", strike)
+	}
+
+	switch {
+	case direction == Up && optType == Call && level <= strike:
+		return fmt.Errorf("invalid up barrier %f for call with strike %f: barrier must be above strike", level, strike)
+	case direction == Down && optType == Put && level >= strike:
+		return fmt.Errorf("invalid down barrier %f for put with strike %f: barrier must be below strike", level, strike)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateBarrier(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction Direction
+		level     float64
+		optType   OptionType
+		want      string // empty when the barrier is valid
+	}{
+		{"up call above strike", Up, 1.20, Call, ""},
+		{"up call at strike", Up, 1.10, Call, "invalid up barrier 1.100000 for call with strike 1.100000: barrier must be above strike"},
+		{"up call below strike", Up, 1.00, Call, "invalid up barrier 1.000000 for call with strike 1.100000"},
+		{"down put below strike", Down, 1.00, Put, ""},
+		{"down put at strike", Down, 1.10, Put, "invalid down barrier 1.100000 for put with strike 1.100000: barrier must be below strike"},
+		{"down put above strike", Down, 1.20, Put, "invalid down barrier 1.200000 for put with strike 1.100000"},
+		// Reverse barriers may sit on either side of the strike
+		{"up put", Up, 1.00, Put, ""},
+		{"down call", Down, 1.20, Call, ""},
+		{"non-positive level", Up, 0, Call, "invalid barrier level 0.000000: must be positive"},
+	}
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, inErr := NewKnockInOption(tt.direction, tt.level, tt.optType, 1.10, maturity, USD, EUR)
+			_, outErr := NewKnockOutOption(tt.direction, tt.level, tt.optType, 1.10, maturity, USD, EUR)
+			for _, err := range []error{inErr, outErr} {
+				switch {
+				case tt.want == "" && err != nil:
+					t.Errorf("unexpected error: %v", err)
+				case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+					t.Errorf("error = %v, want one containing %q", err, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := NewUpAndInOption(1.2, Call, -1, maturity, USD, EUR); err == nil || !strings.Contains(err.Error(), "invalid strike") {
+		t.Errorf("negative strike error = %v, want invalid strike", err)
+	}
+}
+
+func TestKnockInPlusKnockOutIsVanilla(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		direction Direction
+		level     float64
+		optType   OptionType
+	}{
+		{"up call", Up, 1.25, Call},
+		{"down put", Down, 0.95, Put},
+		{"up put", Up, 1.25, Put},
+		{"down call", Down, 0.95, Call},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			knockIn, err := NewKnockInOption(tt.direction, tt.level, tt.optType, 1.10, maturity, USD, EUR)
+			if err != nil {
+				t.Fatal(err)
+			}
+			knockOut, err := NewKnockOutOption(tt.direction, tt.level, tt.optType, 1.10, maturity, USD, EUR)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Normalize(NewCombine(knockIn, knockOut))
+			if err != nil {
+				t.Fatalf("Normalize: %v", err)
+			}
+			vanilla := NewEurOption(tt.optType, 1.10, maturity, USD, EUR)
+			if !Equal(got, vanilla) {
+				t.Errorf("knock-in + knock-out = %s, want %s", got, vanilla)
+			}
+		})
+	}
+}
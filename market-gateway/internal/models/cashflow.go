@@ -0,0 +1,139 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Cashflow is a single payment implied by a contract. Positive amounts are
+// received, negative amounts paid.
+type Cashflow struct {
+	Date       time.Time // zero for immediate (spot) payments
+	Currency   Currency
+	Amount     float64
+	Contingent bool   // true for option legs and legs guarded by When
+	Source     string // path of the leg in the contract tree; empty once aggregated
+}
+
+// Cashflows lists the payments a contract makes, sorted by date and currency
+// and otherwise in tree order:
+//
+//	Spot       receive 1 foreign immediately
+//	Forward    receive 1 foreign, pay FixedRate domestic at maturity
+//	ZCB        receive 1 at maturity
+//	EurOption  call: receive 1 foreign, pay Strike domestic at maturity;
+//	           put: the reverse; both contingent on exercise
+//	When       the guarded contract's flows, contingent on the condition
+//
+// Scale multiplies amounts and Combine concatenates flows. It fails on nil
+// nodes, naming the path to the first one.
+func Cashflows(c Contract) ([]Cashflow, error) {
+	flows, err := Fold[[]Cashflow](c, cashflowVisitor{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cashflows: %w", err)
+	}
+
+	sort.SliceStable(flows, func(i, j int) bool {
+		return cashflowLess(flows[i], flows[j])
+	})
+	return flows, nil
+}
+
+// AggregateCashflows nets flows with the same date, currency and contingency,
+// drops those that net to zero and sorts the result by date and currency
+func AggregateCashflows(flows []Cashflow) []Cashflow {
+	type key struct {
+		date       string
+		currency   Currency
+		contingent bool
+	}
+
+	totals := make(map[key]*Cashflow)
+	var order []key
+	for _, f := range flows {
+		k := key{date: f.Date.Format(dateLayout), currency: f.Currency, contingent: f.Contingent}
+		if total, ok := totals[k]; ok {
+			total.Amount += f.Amount
+			continue
+		}
+		totals[k] = &Cashflow{Date: f.Date, Currency: f.Currency, Amount: f.Amount, Contingent: f.Contingent}
+		order = append(order, k)
+	}
+
+	result := make([]Cashflow, 0, len(order))
+	for _, k := range order {
+		if totals[k].Amount != 0 {
+			result = append(result, *totals[k])
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return cashflowLess(result[i], result[j])
+	})
+	return result
+}
+
+// cashflowLess orders flows by date, currency, then deterministic before contingent
+func cashflowLess(a, b Cashflow) bool {
+	if !sameDate(a.Date, b.Date) {
+		return a.Date.Before(b.Date)
+	}
+	if a.Currency != b.Currency {
+		return a.Currency < b.Currency
+	}
+	return !a.Contingent && b.Contingent
+}
+
+type cashflowVisitor struct{}
+
+func (cashflowVisitor) VisitZero(string, Zero) ([]Cashflow, error) { return nil, nil }
+
+func (cashflowVisitor) VisitSpot(path string, c Spot) ([]Cashflow, error) {
+	return []Cashflow{{Currency: c.Foreign, Amount: 1, Source: nodePath(path, "Spot")}}, nil
+}
+
+func (cashflowVisitor) VisitForward(path string, c Forward) ([]Cashflow, error) {
+	source := nodePath(path, "Forward")
+	return []Cashflow{
+		{Date: c.Maturity, Currency: c.Foreign, Amount: 1, Source: source},
+		{Date: c.Maturity, Currency: c.Domestic, Amount: -c.FixedRate, Source: source},
+	}, nil
+}
+
+func (cashflowVisitor) VisitEurOption(path string, c EurOption) ([]Cashflow, error) {
+	sign := 1.0
+	if c.Type == Put {
+		sign = -1
+	}
+	source := nodePath(path, "EurOption")
+	return []Cashflow{
+		{Date: c.Maturity, Currency: c.Foreign, Amount: sign, Contingent: true, Source: source},
+		{Date: c.Maturity, Currency: c.Domestic, Amount: -sign * c.Strike, Contingent: true, Source: source},
+	}, nil
+}
+
+func (cashflowVisitor) VisitZCB(path string, c ZCB) ([]Cashflow, error) {
+	return []Cashflow{{Date: c.Maturity, Currency: c.Currency, Amount: 1, Source: nodePath(path, "ZCB")}}, nil
+}
+
+func (cashflowVisitor) VisitScale(_ string, c Scale, flows []Cashflow) ([]Cashflow, error) {
+	for i := range flows {
+		flows[i].Amount *= c.Notional
+	}
+	return flows, nil
+}
+
+func (cashflowVisitor) VisitCombine(_ string, _ Combine, left, right []Cashflow) ([]Cashflow, error) {
+	return append(left, right...), nil
+}
+
+func (cashflowVisitor) VisitWhen(_ string, _ When, flows []Cashflow) ([]Cashflow, error) {
+	for i := range flows {
+		flows[i].Contingent = true
+	}
+	return flows, nil
+}
+
+func (cashflowVisitor) VisitNamed(_ string, _ Named, flows []Cashflow) ([]Cashflow, error) {
+	return flows, nil
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCashflowsPerNode(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	barrier := NewBarrier(Up, 1.3, NewSpotRate(USD, EUR))
+
+	tests := []struct {
+		name     string
+		contract Contract
+		want     []Cashflow
+	}{
+		{"zero", Zero{}, nil},
+		{"spot", NewSpot(USD, EUR), []Cashflow{
+			{Currency: EUR, Amount: 1, Source: "Spot"},
+		}},
+		{"forward", NewForward(maturity, 1.12, USD, EUR), []Cashflow{
+			{Date: maturity, Currency: USD, Amount: -1.12, Source: "Forward"},
+			{Date: maturity, Currency: EUR, Amount: 1, Source: "Forward"},
+		}},
+		{"zcb", NewZCB(JPY, maturity), []Cashflow{
+			{Date: maturity, Currency: JPY, Amount: 1, Source: "ZCB"},
+		}},
+		{"call", NewCallOption(1.1, maturity, USD, EUR), []Cashflow{
+			{Date: maturity, Currency: USD, Amount: -1.1, Contingent: true, Source: "EurOption"},
+			{Date: maturity, Currency: EUR, Amount: 1, Contingent: true, Source: "EurOption"},
+		}},
+		{"put", NewPutOption(1.1, maturity, USD, EUR), []Cashflow{
+			{Date: maturity, Currency: USD, Amount: 1.1, Contingent: true, Source: "EurOption"},
+			{Date: maturity, Currency: EUR, Amount: -1, Contingent: true, Source: "EurOption"},
+		}},
+		{"when", NewWhen(barrier, NewZCB(USD, maturity)), []Cashflow{
+			{Date: maturity, Currency: USD, Amount: 1, Contingent: true, Source: "When.Contract"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Cashflows(tt.contract)
+			if err != nil {
+				t.Fatalf("Cashflows: %v", err)
+			}
+			assertCashflows(t, got, tt.want)
+		})
+	}
+}
+
+func TestCashflowsScaleAndCombine(t *testing.T) {
+	early := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	contract := NewNamed("book", NewCombine(
+		NewScale(1e6, NewForward(late, 1.12, USD, EUR)),
+		NewScale(-2, NewZCB(USD, early)),
+	))
+
+	got, err := Cashflows(contract)
+	if err != nil {
+		t.Fatalf("Cashflows: %v", err)
+	}
+	// Sorted by date, then currency in registry order
+	assertCashflows(t, got, []Cashflow{
+		{Date: early, Currency: USD, Amount: -2, Source: "Named.Contract.Combine.Right.Scale.Contract"},
+		{Date: late, Currency: USD, Amount: -1.12e6, Source: "Named.Contract.Combine.Left.Scale.Contract"},
+		{Date: late, Currency: EUR, Amount: 1e6, Source: "Named.Contract.Combine.Left.Scale.Contract"},
+	})
+}
+
+func TestCashflowsRejectsNilNodes(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	flows, err := Cashflows(NewCombine(NewZCB(USD, maturity), nil))
+	if err == nil {
+		t.Fatalf("Cashflows = %+v, want an error", flows)
+	}
+	if !strings.Contains(err.Error(), "Combine.Right") {
+		t.Errorf("error %q does not name the nil node", err)
+	}
+}
+
+func TestAggregateCashflowsNets(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	// The short EUR bond cancels the forward's EUR leg and the USD flows net;
+	// the put's flows stay apart as contingent
+	contract := CombineAll(
+		NewForward(maturity, 1.1, USD, EUR),
+		NewScale(-1, NewZCB(EUR, maturity)),
+		NewScale(0.5, NewZCB(USD, maturity)),
+		NewPutOption(1.2, maturity, USD, EUR),
+	)
+	flows, err := Cashflows(contract)
+	if err != nil {
+		t.Fatalf("Cashflows: %v", err)
+	}
+
+	assertCashflows(t, AggregateCashflows(flows), []Cashflow{
+		{Date: maturity, Currency: USD, Amount: -0.6},
+		{Date: maturity, Currency: USD, Amount: 1.2, Contingent: true},
+		{Date: maturity, Currency: EUR, Amount: -1, Contingent: true},
+	})
+}
+
+func assertCashflows(t *testing.T, got, want []Cashflow) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d flows %+v, want %d %+v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if !g.Date.Equal(w.Date) || g.Currency != w.Currency || g.Contingent != w.Contingent ||
+			g.Source != w.Source || math.Abs(g.Amount-w.Amount) > 1e-9 {
+			t.Errorf("flow %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -5,43 +5,6 @@ import (
 	"time"
 )
 
-// Currency represents a currency code
-type Currency int
-
-const (
-	USD Currency = iota
-	EUR
-	GBP
-	JPY
-	CHF
-	AUD
-	CAD
-)
-
-var currencyNames = map[Currency]string{
-	USD: "USD",
-	EUR: "EUR",
-	GBP: "GBP",
-	JPY: "JPY",
-	CHF: "CHF",
-	AUD: "AUD",
-	CAD: "CAD",
-}
-
-func (c Currency) String() string {
-	return currencyNames[c]
-}
-
-// ParseCurrency parses a currency string to Currency type
-func ParseCurrency(s string) (Currency, error) {
-	for c, name := range currencyNames {
-		if name == s {
-			return c, nil
-		}
-	}
-	return 0, fmt.Errorf("unknown currency: %s", s)
-}
-
 // OptionType represents call or put option
 type OptionType int
 
@@ -57,18 +20,30 @@ func (o OptionType) String() string {
 	return "PUT"
 }
 
-// Contract is a marker interface for all contract types
+// ParseOptionType parses an option type string ("CALL" or "PUT")
+func ParseOptionType(s string) (OptionType, error) {
+	switch s {
+	case "CALL":
+		return Call, nil
+	case "PUT":
+		return Put, nil
+	}
+	return 0, fmt.Errorf("unknown option type: %s", s)
+}
+
+// Contract is the interface of all contract types
 // This will map to the Haskell Contract GADT via protobuf
 type Contract interface {
-	isContract()
+	// accept calls the dispatcher method for the node's type (see Visitor)
+	accept(d dispatcher)
 	String() string
 }
 
 // Zero represents a contract with zero value
 type Zero struct{}
 
-func (Zero) isContract()     {}
-func (Zero) String() string { return "Zero" }
+func (c Zero) accept(d dispatcher) { d.zero(c) }
+func (Zero) String() string        { return "Zero" }
 
 // Spot represents a spot FX contract
 type Spot struct {
@@ -76,7 +51,7 @@ type Spot struct {
 	Foreign  Currency
 }
 
-func (s Spot) isContract() {}
+func (s Spot) accept(d dispatcher) { d.spot(s) }
 func (s Spot) String() string {
 	return fmt.Sprintf("Spot(%s/%s)", s.Foreign, s.Domestic)
 }
@@ -89,7 +64,7 @@ type Forward struct {
 	Foreign   Currency
 }
 
-func (f Forward) isContract() {}
+func (f Forward) accept(d dispatcher) { d.forward(f) }
 func (f Forward) String() string {
 	return fmt.Sprintf("Forward(%s/%s, Strike: %.4f, Maturity: %s)",
 		f.Foreign, f.Domestic, f.FixedRate, f.Maturity.Format("2006-01-02"))
@@ -104,7 +79,7 @@ type EurOption struct {
 	Foreign  Currency
 }
 
-func (e EurOption) isContract() {}
+func (e EurOption) accept(d dispatcher) { d.eurOption(e) }
 func (e EurOption) String() string {
 	return fmt.Sprintf("EurOption(%s, %s/%s, Strike: %.4f, Maturity: %s)",
 		e.Type, e.Foreign, e.Domestic, e.Strike, e.Maturity.Format("2006-01-02"))
@@ -116,7 +91,7 @@ type ZCB struct {
 	Maturity time.Time
 }
 
-func (z ZCB) isContract() {}
+func (z ZCB) accept(d dispatcher) { d.zcb(z) }
 func (z ZCB) String() string {
 	return fmt.Sprintf("ZCB(%s, Maturity: %s)",
 		z.Currency, z.Maturity.Format("2006-01-02"))
@@ -128,7 +103,7 @@ type Scale struct {
 	Contract Contract
 }
 
-func (s Scale) isContract() {}
+func (s Scale) accept(d dispatcher) { d.scale(s) }
 func (s Scale) String() string {
 	return fmt.Sprintf("Scale(%.2f, %s)", s.Notional, s.Contract)
 }
@@ -139,11 +114,34 @@ type Combine struct {
 	Right Contract
 }
 
-func (c Combine) isContract() {}
+func (c Combine) accept(d dispatcher) { d.combine(c) }
 func (c Combine) String() string {
 	return fmt.Sprintf("Combine(%s, %s)", c.Left, c.Right)
 }
 
+// When represents a contract that is only acquired once a condition holds
+type When struct {
+	Condition BoolObservable
+	Contract  Contract
+}
+
+func (w When) accept(d dispatcher) { d.when(w) }
+func (w When) String() string {
+	return fmt.Sprintf("When(%s, %s)", w.Condition, w.Contract)
+}
+
+// Named tags a contract with a product name (e.g. "straddle") for logging
+// and breakdowns. It has no Haskell counterpart and is transparent on the wire.
+type Named struct {
+	Name     string
+	Contract Contract
+}
+
+func (n Named) accept(d dispatcher) { d.named(n) }
+func (n Named) String() string {
+	return fmt.Sprintf("Named(%s, %s)", n.Name, n.Contract)
+}
+
 // Builder functions for ergonomic contract construction
 
 // NewSpot creates a new spot contract
@@ -199,6 +197,22 @@ func NewCombine(left, right Contract) Combine {
 	}
 }
 
+// NewWhen creates a contract conditional on an observable
+func NewWhen(condition BoolObservable, contract Contract) When {
+	return When{
+		Condition: condition,
+		Contract:  contract,
+	}
+}
+
+// NewNamed tags a contract with a product name
+func NewNamed(name string, contract Contract) Named {
+	return Named{
+		Name:     name,
+		Contract: contract,
+	}
+}
+
 // Example helper functions for common patterns
 
 // NewCallOption creates a call option
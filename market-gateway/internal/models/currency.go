@@ -0,0 +1,188 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Currency identifies a currency in the registry. The built-in constants
+// below are always registered; further currencies are added with
+// RegisterCurrency or LoadCurrencies, usually from configuration.
+//
+// Values other than the constants are assigned in registration order, so
+// they differ between processes loading different configurations. Persist
+// and transmit currencies by code, never by int value.
+type Currency int
+
+const (
+	USD Currency = iota
+	EUR
+	GBP
+	JPY
+	CHF
+	AUD
+	CAD
+)
+
+// CurrencyInfo holds the static reference data of a currency
+type CurrencyInfo struct {
+	Code           string // ISO 4217 alphabetic code, e.g. "USD"
+	Numeric        int    // ISO 4217 numeric code, 0 if none (e.g. CNH)
+	MinorUnits     int    // decimal places, e.g. 2 for USD, 0 for JPY
+	SettlementDays int    // spot settlement lag in business days
+	Calendar       string // holiday calendar ID, e.g. "USNY"
+}
+
+// defaultCurrencies is the reference data registered at start-up. The first
+// seven entries back the Currency constants and must stay in that order.
+var defaultCurrencies = []CurrencyInfo{
+	{Code: "USD", Numeric: 840, MinorUnits: 2, SettlementDays: 2, Calendar: "USNY"},
+	{Code: "EUR", Numeric: 978, MinorUnits: 2, SettlementDays: 2, Calendar: "TARGET"},
+	{Code: "GBP", Numeric: 826, MinorUnits: 2, SettlementDays: 2, Calendar: "GBLO"},
+	{Code: "JPY", Numeric: 392, MinorUnits: 0, SettlementDays: 2, Calendar: "JPTO"},
+	{Code: "CHF", Numeric: 756, MinorUnits: 2, SettlementDays: 2, Calendar: "CHZU"},
+	{Code: "AUD", Numeric: 36, MinorUnits: 2, SettlementDays: 2, Calendar: "AUSY"},
+	{Code: "CAD", Numeric: 124, MinorUnits: 2, SettlementDays: 1, Calendar: "CATO"},
+	{Code: "NZD", Numeric: 554, MinorUnits: 2, SettlementDays: 2, Calendar: "NZAU"},
+	{Code: "NOK", Numeric: 578, MinorUnits: 2, SettlementDays: 2, Calendar: "NOOS"},
+	{Code: "SEK", Numeric: 752, MinorUnits: 2, SettlementDays: 2, Calendar: "SEST"},
+	{Code: "DKK", Numeric: 208, MinorUnits: 2, SettlementDays: 2, Calendar: "DKCO"},
+	{Code: "MXN", Numeric: 484, MinorUnits: 2, SettlementDays: 2, Calendar: "MXMC"},
+	{Code: "CNH", Numeric: 0, MinorUnits: 2, SettlementDays: 2, Calendar: "HKHK"},
+	{Code: "HKD", Numeric: 344, MinorUnits: 2, SettlementDays: 2, Calendar: "HKHK"},
+	{Code: "SGD", Numeric: 702, MinorUnits: 2, SettlementDays: 2, Calendar: "SGSI"},
+}
+
+// currencyRegistry maps codes to Currency values. Entries are never removed,
+// so a Currency stays valid for the life of the process.
+type currencyRegistry struct {
+	mu     sync.RWMutex
+	infos  []CurrencyInfo // indexed by Currency
+	byCode map[string]Currency
+}
+
+var registry = newCurrencyRegistry(defaultCurrencies)
+
+func newCurrencyRegistry(infos []CurrencyInfo) *currencyRegistry {
+	r := &currencyRegistry{byCode: make(map[string]Currency)}
+	if err := r.load(infos); err != nil {
+		panic(fmt.Sprintf("invalid default currencies: %v", err))
+	}
+	return r
+}
+
+func (c Currency) String() string {
+	info, ok := c.Info()
+	if !ok {
+		return ""
+	}
+	return info.Code
+}
+
+// Info returns the reference data of a registered currency
+func (c Currency) Info() (CurrencyInfo, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	if c < 0 || int(c) >= len(registry.infos) {
+		return CurrencyInfo{}, false
+	}
+	return registry.infos[c], true
+}
+
+// ParseCurrency parses an ISO 4217 code, in any case, to a registered Currency
+func ParseCurrency(s string) (Currency, error) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	c, ok := registry.byCode[strings.ToUpper(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency: %s", s)
+	}
+	return c, nil
+}
+
+// Currencies returns the reference data of every registered currency
+func Currencies() []CurrencyInfo {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	return append([]CurrencyInfo(nil), registry.infos...)
+}
+
+// RegisterCurrency adds a currency, or replaces the reference data of an
+// already registered code, and returns its Currency value
+func RegisterCurrency(info CurrencyInfo) (Currency, error) {
+	if err := LoadCurrencies([]CurrencyInfo{info}); err != nil {
+		return 0, err
+	}
+	return ParseCurrency(info.Code)
+}
+
+// LoadCurrencies registers a batch of currencies. The batch is validated as a
+// whole and nothing is registered if any entry is invalid.
+func LoadCurrencies(infos []CurrencyInfo) error {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	return registry.load(infos)
+}
+
+func (r *currencyRegistry) load(infos []CurrencyInfo) error {
+	// Numeric codes must stay unique across the registry and the batch
+	numerics := make(map[int]string)
+	for _, info := range r.infos {
+		if info.Numeric != 0 {
+			numerics[info.Numeric] = info.Code
+		}
+	}
+	seen := make(map[string]bool, len(infos))
+	for i, info := range infos {
+		if err := validateCurrencyInfo(info); err != nil {
+			return fmt.Errorf("currency %d: %w", i, err)
+		}
+		if seen[info.Code] {
+			return fmt.Errorf("currency %d: duplicate code %s", i, info.Code)
+		}
+		seen[info.Code] = true
+
+		if info.Numeric != 0 {
+			if other, ok := numerics[info.Numeric]; ok && other != info.Code {
+				return fmt.Errorf("currency %d: %s: numeric code %03d is already used by %s", i, info.Code, info.Numeric, other)
+			}
+			numerics[info.Numeric] = info.Code
+		}
+	}
+
+	for _, info := range infos {
+		if c, ok := r.byCode[info.Code]; ok {
+			r.infos[c] = info
+			continue
+		}
+		r.byCode[info.Code] = Currency(len(r.infos))
+		r.infos = append(r.infos, info)
+	}
+	return nil
+}
+
+func validateCurrencyInfo(info CurrencyInfo) error {
+	if len(info.Code) != 3 {
+		return fmt.Errorf("invalid code %q: must be 3 uppercase letters", info.Code)
+	}
+	for _, r := range info.Code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("invalid code %q: must be 3 uppercase letters", info.Code)
+		}
+	}
+	if info.Numeric < 0 || info.Numeric > 999 {
+		return fmt.Errorf("%s: invalid numeric code %d: must be between 0 and 999", info.Code, info.Numeric)
+	}
+	if info.MinorUnits < 0 || info.MinorUnits > 4 {
+		return fmt.Errorf("%s: invalid minor units %d: must be between 0 and 4", info.Code, info.MinorUnits)
+	}
+	if info.SettlementDays < 0 || info.SettlementDays > 5 {
+		return fmt.Errorf("%s: invalid settlement days %d: must be between 0 and 5", info.Code, info.SettlementDays)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCurrencyIsCaseInsensitive(t *testing.T) {
+	for _, code := range []string{"EUR", "eur", "Eur"} {
+		c, err := ParseCurrency(code)
+		if err != nil {
+			t.Fatalf("ParseCurrency(%q): %v", code, err)
+		}
+		if c != EUR {
+			t.Errorf("ParseCurrency(%q) = %s, want EUR", code, c)
+		}
+	}
+	if _, err := ParseCurrency("XXX"); err == nil {
+		t.Error("ParseCurrency(XXX) should fail")
+	}
+}
+
+func TestDefaultCurrenciesBackTheConstants(t *testing.T) {
+	for c, code := range map[Currency]string{USD: "USD", EUR: "EUR", GBP: "GBP", JPY: "JPY", CHF: "CHF", AUD: "AUD", CAD: "CAD"} {
+		if c.String() != code {
+			t.Errorf("Currency(%d) = %q, want %s", int(c), c.String(), code)
+		}
+	}
+	info, ok := JPY.Info()
+	if !ok || info.MinorUnits != 0 || info.Numeric != 392 {
+		t.Errorf("JPY info = %+v, want 0 minor units and numeric 392", info)
+	}
+}
+
+func TestLoadCurrenciesRejectsInvalidBatches(t *testing.T) {
+	before := Currencies()
+
+	tests := []struct {
+		name  string
+		infos []CurrencyInfo
+		want  string
+	}{
+		{"duplicate code", []CurrencyInfo{
+			{Code: "PLN", Numeric: 985, MinorUnits: 2, SettlementDays: 2},
+			{Code: "PLN", Numeric: 985, MinorUnits: 2, SettlementDays: 2},
+		}, "currency 1: duplicate code PLN"},
+		{"numeric used by another code", []CurrencyInfo{
+			{Code: "CZK", Numeric: 840, MinorUnits: 2, SettlementDays: 2},
+		}, "currency 0: CZK: numeric code 840 is already used by USD"},
+		{"lowercase code", []CurrencyInfo{{Code: "pln", MinorUnits: 2}}, `invalid code "pln"`},
+		{"minor units", []CurrencyInfo{{Code: "PLN", MinorUnits: 5}}, "invalid minor units 5"},
+		{"settlement days", []CurrencyInfo{{Code: "PLN", SettlementDays: 6}}, "invalid settlement days 6"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadCurrencies(tt.infos)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+
+	// Rejected batches register nothing
+	if after := Currencies(); len(after) != len(before) {
+		t.Errorf("registry grew from %d to %d currencies", len(before), len(after))
+	}
+	if _, err := ParseCurrency("PLN"); err == nil {
+		t.Error("PLN was registered by a rejected batch")
+	}
+}
+
+func TestRegisterCurrency(t *testing.T) {
+	c, err := RegisterCurrency(CurrencyInfo{Code: "ZAR", Numeric: 710, MinorUnits: 2, SettlementDays: 2, Calendar: "ZAJO"})
+	if err != nil {
+		t.Fatalf("RegisterCurrency: %v", err)
+	}
+	if c.String() != "ZAR" {
+		t.Errorf("registered currency = %q, want ZAR", c.String())
+	}
+
+	// Registering a code again replaces its data and keeps its value
+	again, err := RegisterCurrency(CurrencyInfo{Code: "ZAR", Numeric: 710, MinorUnits: 2, SettlementDays: 1, Calendar: "ZAJO"})
+	if err != nil {
+		t.Fatalf("RegisterCurrency: %v", err)
+	}
+	if info, _ := again.Info(); again != c || info.SettlementDays != 1 {
+		t.Errorf("re-registered ZAR = %d %+v, want %d with 1 settlement day", int(again), info, int(c))
+	}
+}
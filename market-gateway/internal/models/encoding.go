@@ -0,0 +1,518 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Contracts are serialized as a tagged union following the conceptual JSON in
+// ARCHITECTURE.md: every node is an object with a single key naming its type,
+// and fields use the protobuf names. Currencies, option types, directions and
+// dates use their string forms.
+//
+//	{"scale": {"notional": 1000000, "contract": {"eur_option": {
+//	    "option_type": "CALL", "strike": 1.15, "maturity": "2025-12-31",
+//	    "domestic": "USD", "foreign": "EUR"}}}}
+
+// MarshalContractJSON encodes a contract tree as JSON
+func MarshalContractJSON(c Contract) ([]byte, error) {
+	node, err := Fold[object](c, nodeEncoder{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(node)
+}
+
+// UnmarshalContractJSON decodes a contract tree from JSON, rejecting unknown fields
+func UnmarshalContractJSON(data []byte) (Contract, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var node any
+	if err := dec.Decode(&node); err != nil {
+		return nil, fmt.Errorf("failed to parse contract JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("failed to parse contract JSON: unexpected data after contract")
+	}
+
+	return contractFromNode(node, "contract")
+}
+
+// MarshalContractYAML encodes a contract tree as YAML
+func MarshalContractYAML(c Contract) ([]byte, error) {
+	node, err := Fold[object](c, nodeEncoder{})
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(node)
+}
+
+// UnmarshalContractYAML decodes a contract tree from YAML, rejecting unknown fields
+func UnmarshalContractYAML(data []byte) (Contract, error) {
+	var node any
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse contract YAML: %w", err)
+	}
+
+	return contractFromNode(node, "contract")
+}
+
+// dateLayout is the ISO 8601 date format used for serialized dates, which
+// are written as UTC calendar dates
+const dateLayout = "2006-01-02"
+
+// object is the generic form of a serialized node
+type object = map[string]any
+
+func tagged(tag string, fields object) object {
+	return object{tag: fields}
+}
+
+// nodeEncoder folds a contract into its tagged-union form
+type nodeEncoder struct{}
+
+func (nodeEncoder) VisitZero(string, Zero) (object, error) {
+	return tagged("zero", object{}), nil
+}
+
+func (nodeEncoder) VisitSpot(_ string, c Spot) (object, error) {
+	return tagged("spot", object{
+		"domestic": c.Domestic.String(),
+		"foreign":  c.Foreign.String(),
+	}), nil
+}
+
+func (nodeEncoder) VisitForward(_ string, c Forward) (object, error) {
+	return tagged("forward", object{
+		"maturity":   c.Maturity.UTC().Format(dateLayout),
+		"fixed_rate": c.FixedRate,
+		"domestic":   c.Domestic.String(),
+		"foreign":    c.Foreign.String(),
+	}), nil
+}
+
+func (nodeEncoder) VisitEurOption(_ string, c EurOption) (object, error) {
+	return tagged("eur_option", object{
+		"option_type": c.Type.String(),
+		"strike":      c.Strike,
+		"maturity":    c.Maturity.UTC().Format(dateLayout),
+		"domestic":    c.Domestic.String(),
+		"foreign":     c.Foreign.String(),
+	}), nil
+}
+
+func (nodeEncoder) VisitZCB(_ string, c ZCB) (object, error) {
+	return tagged("zcb", object{
+		"currency": c.Currency.String(),
+		"maturity": c.Maturity.UTC().Format(dateLayout),
+	}), nil
+}
+
+func (nodeEncoder) VisitScale(_ string, c Scale, contract object) (object, error) {
+	return tagged("scale", object{
+		"notional": c.Notional,
+		"contract": contract,
+	}), nil
+}
+
+func (nodeEncoder) VisitCombine(_ string, _ Combine, left, right object) (object, error) {
+	return tagged("combine", object{
+		"left":  left,
+		"right": right,
+	}), nil
+}
+
+func (nodeEncoder) VisitWhen(path string, c When, contract object) (object, error) {
+	condition, err := observableToNode(c.Condition, ChildPath(path, "When", "Condition"))
+	if err != nil {
+		return nil, err
+	}
+	return tagged("when", object{
+		"condition": condition,
+		"contract":  contract,
+	}), nil
+}
+
+func (nodeEncoder) VisitNamed(_ string, c Named, contract object) (object, error) {
+	return tagged("named", object{
+		"name":     c.Name,
+		"contract": contract,
+	}), nil
+}
+
+func observableToNode(o Observable, path string) (object, error) {
+	switch o := o.(type) {
+	case nil:
+		return nil, fmt.Errorf("%s: observable is nil", path)
+
+	case ConstBool:
+		return tagged("const_bool", object{"value": o.Value}), nil
+
+	case ConstDouble:
+		return tagged("const_double", object{"value": o.Value}), nil
+
+	case SpotRate:
+		return tagged("spot_rate", object{
+			"domestic": o.Domestic.String(),
+			"foreign":  o.Foreign.String(),
+		}), nil
+
+	case FwdRate:
+		return tagged("fwd_rate", object{
+			"domestic": o.Domestic.String(),
+			"foreign":  o.Foreign.String(),
+			"maturity": o.Maturity.UTC().Format(dateLayout),
+		}), nil
+
+	case Barrier:
+		underlying, err := observableToNode(o.Underlying, ChildPath(path, "Barrier", "Underlying"))
+		if err != nil {
+			return nil, err
+		}
+		return tagged("barrier", object{
+			"direction":  o.Direction.String(),
+			"level":      o.Level,
+			"underlying": underlying,
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("%s: unsupported observable type %T", path, o)
+	}
+}
+
+// fieldReader gives strict, path-aware access to the fields of one node
+type fieldReader struct {
+	path   string
+	fields object
+	err    error
+}
+
+// unwrap splits a tagged node into its tag and a reader over its fields
+func unwrap(node any, path string) (string, *fieldReader, error) {
+	obj, err := asObject(node, path)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(obj) != 1 {
+		return "", nil, fmt.Errorf("%s: expected exactly one type key, got %d (%s)", path, len(obj), strings.Join(sortedKeys(obj), ", "))
+	}
+
+	for tag, value := range obj {
+		fields, err := asObject(value, path+"."+tag)
+		if err != nil {
+			return "", nil, err
+		}
+		return tag, &fieldReader{path: path + "." + tag, fields: fields}, nil
+	}
+	panic("unreachable")
+}
+
+// done reports the first field error, or any field that was not consumed
+func (r *fieldReader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.fields) > 0 {
+		return fmt.Errorf("%s: unknown field %q", r.path, sortedKeys(r.fields)[0])
+	}
+	return nil
+}
+
+func (r *fieldReader) take(name string) (any, string, bool) {
+	path := r.path + "." + name
+	if r.err != nil {
+		return nil, path, false
+	}
+	value, ok := r.fields[name]
+	if !ok {
+		r.err = fmt.Errorf("%s: missing field", path)
+		return nil, path, false
+	}
+	delete(r.fields, name)
+	return value, path, true
+}
+
+func (r *fieldReader) node(name string) (any, string) {
+	value, path, _ := r.take(name)
+	return value, path
+}
+
+func (r *fieldReader) string(name string) string {
+	value, path, ok := r.take(name)
+	if !ok {
+		return ""
+	}
+	s, isString := value.(string)
+	if !isString {
+		r.err = fmt.Errorf("%s: expected a string, got %T", path, value)
+	}
+	return s
+}
+
+func (r *fieldReader) bool(name string) bool {
+	value, path, ok := r.take(name)
+	if !ok {
+		return false
+	}
+	b, isBool := value.(bool)
+	if !isBool {
+		r.err = fmt.Errorf("%s: expected a boolean, got %T", path, value)
+	}
+	return b
+}
+
+func (r *fieldReader) float(name string) float64 {
+	value, path, ok := r.take(name)
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			r.err = fmt.Errorf("%s: invalid number %q", path, v)
+		}
+		return f
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		r.err = fmt.Errorf("%s: expected a number, got %T", path, value)
+		return 0
+	}
+}
+
+func (r *fieldReader) currency(name string) Currency {
+	value, path, ok := r.take(name)
+	if !ok {
+		return 0
+	}
+	s, isString := value.(string)
+	if !isString {
+		r.err = fmt.Errorf("%s: expected a currency code, got %T", path, value)
+		return 0
+	}
+	c, err := ParseCurrency(s)
+	if err != nil {
+		r.err = fmt.Errorf("%s: %w", path, err)
+	}
+	return c
+}
+
+func (r *fieldReader) optionType(name string) OptionType {
+	s := r.string(name)
+	if r.err != nil {
+		return 0
+	}
+	o, err := ParseOptionType(s)
+	if err != nil {
+		r.err = fmt.Errorf("%s.%s: %w", r.path, name, err)
+	}
+	return o
+}
+
+func (r *fieldReader) direction(name string) Direction {
+	s := r.string(name)
+	if r.err != nil {
+		return 0
+	}
+	d, err := ParseDirection(s)
+	if err != nil {
+		r.err = fmt.Errorf("%s.%s: %w", r.path, name, err)
+	}
+	return d
+}
+
+func (r *fieldReader) date(name string) time.Time {
+	value, path, ok := r.take(name)
+	if !ok {
+		return time.Time{}
+	}
+	switch v := value.(type) {
+	case time.Time:
+		// YAML resolves unquoted dates to timestamps
+		return v
+	case string:
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			r.err = fmt.Errorf("%s: invalid date %q: expected YYYY-MM-DD", path, v)
+		}
+		return t
+	default:
+		r.err = fmt.Errorf("%s: expected a date, got %T", path, value)
+		return time.Time{}
+	}
+}
+
+func contractFromNode(node any, path string) (Contract, error) {
+	tag, r, err := unwrap(node, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Contract
+	switch tag {
+	case "zero":
+		c = Zero{}
+
+	case "spot":
+		c = NewSpot(r.currency("domestic"), r.currency("foreign"))
+
+	case "forward":
+		c = NewForward(r.date("maturity"), r.float("fixed_rate"), r.currency("domestic"), r.currency("foreign"))
+
+	case "eur_option":
+		c = NewEurOption(r.optionType("option_type"), r.float("strike"), r.date("maturity"), r.currency("domestic"), r.currency("foreign"))
+
+	case "zcb":
+		c = NewZCB(r.currency("currency"), r.date("maturity"))
+
+	case "scale":
+		notional := r.float("notional")
+		inner, innerPath := r.node("contract")
+		if r.err != nil {
+			return nil, r.err
+		}
+		contract, err := contractFromNode(inner, innerPath)
+		if err != nil {
+			return nil, err
+		}
+		c = NewScale(notional, contract)
+
+	case "combine":
+		left, leftPath := r.node("left")
+		right, rightPath := r.node("right")
+		if r.err != nil {
+			return nil, r.err
+		}
+		l, err := contractFromNode(left, leftPath)
+		if err != nil {
+			return nil, err
+		}
+		rt, err := contractFromNode(right, rightPath)
+		if err != nil {
+			return nil, err
+		}
+		c = NewCombine(l, rt)
+
+	case "when":
+		condNode, condPath := r.node("condition")
+		inner, innerPath := r.node("contract")
+		if r.err != nil {
+			return nil, r.err
+		}
+		obs, err := observableFromNode(condNode, condPath)
+		if err != nil {
+			return nil, err
+		}
+		condition, ok := obs.(BoolObservable)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a boolean observable, got %s", condPath, obs)
+		}
+		contract, err := contractFromNode(inner, innerPath)
+		if err != nil {
+			return nil, err
+		}
+		c = NewWhen(condition, contract)
+
+	case "named":
+		name := r.string("name")
+		inner, innerPath := r.node("contract")
+		if r.err != nil {
+			return nil, r.err
+		}
+		contract, err := contractFromNode(inner, innerPath)
+		if err != nil {
+			return nil, err
+		}
+		c = NewNamed(name, contract)
+
+	default:
+		return nil, fmt.Errorf("%s: unknown contract type %q", path, tag)
+	}
+
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func observableFromNode(node any, path string) (Observable, error) {
+	tag, r, err := unwrap(node, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var o Observable
+	switch tag {
+	case "const_bool":
+		o = NewConstBool(r.bool("value"))
+
+	case "const_double":
+		o = NewConstDouble(r.float("value"))
+
+	case "spot_rate":
+		o = NewSpotRate(r.currency("domestic"), r.currency("foreign"))
+
+	case "fwd_rate":
+		o = NewFwdRate(r.currency("domestic"), r.currency("foreign"), r.date("maturity"))
+
+	case "barrier":
+		direction := r.direction("direction")
+		level := r.float("level")
+		inner, innerPath := r.node("underlying")
+		if r.err != nil {
+			return nil, r.err
+		}
+		obs, err := observableFromNode(inner, innerPath)
+		if err != nil {
+			return nil, err
+		}
+		underlying, ok := obs.(DoubleObservable)
+		if !ok {
+			return nil, fmt.Errorf("%s: expected a numeric observable, got %s", innerPath, obs)
+		}
+		o = NewBarrier(direction, level, underlying)
+
+	default:
+		return nil, fmt.Errorf("%s: unknown observable type %q", path, tag)
+	}
+
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func asObject(node any, path string) (object, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		return v, nil
+	case nil:
+		return nil, fmt.Errorf("%s: value is null", path)
+	default:
+		return nil, fmt.Errorf("%s: expected an object, got %T", path, node)
+	}
+}
+
+func sortedKeys(obj object) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// encodingCases covers every contract node and observable type
+func encodingCases() map[string]Contract {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	call := NewCallOption(1.1, maturity, USD, EUR)
+	return map[string]Contract{
+		"zero":         Zero{},
+		"spot":         NewSpot(JPY, USD),
+		"forward":      NewForward(maturity, 1.12, USD, EUR),
+		"call":         call,
+		"put":          NewPutOption(150, maturity, JPY, USD),
+		"zcb":          NewZCB(GBP, maturity),
+		"scale":        NewScale(-1e6, call),
+		"combine":      NewCombine(call, NewZCB(USD, maturity)),
+		"named":        NewNamed("leg", call),
+		"const bool":   NewWhen(NewConstBool(true), call),
+		"spot barrier": NewWhen(NewBarrier(Up, 1.3, NewSpotRate(USD, EUR)), call),
+		"fwd barrier":  NewWhen(NewBarrier(Down, 140, NewFwdRate(JPY, USD, maturity)), call),
+		"const double": NewWhen(NewBarrier(Down, 1, NewConstDouble(2)), Zero{}),
+	}
+}
+
+func TestContractJSONRoundTrips(t *testing.T) {
+	for name, c := range encodingCases() {
+		t.Run(name, func(t *testing.T) {
+			data, err := MarshalContractJSON(c)
+			if err != nil {
+				t.Fatalf("MarshalContractJSON: %v", err)
+			}
+			got, err := UnmarshalContractJSON(data)
+			if err != nil {
+				t.Fatalf("UnmarshalContractJSON(%s): %v", data, err)
+			}
+			if !Equal(got, c) {
+				t.Errorf("round trip of %s = %s", c, got)
+			}
+		})
+	}
+}
+
+func TestContractYAMLRoundTrips(t *testing.T) {
+	for name, c := range encodingCases() {
+		t.Run(name, func(t *testing.T) {
+			data, err := MarshalContractYAML(c)
+			if err != nil {
+				t.Fatalf("MarshalContractYAML: %v", err)
+			}
+			got, err := UnmarshalContractYAML(data)
+			if err != nil {
+				t.Fatalf("UnmarshalContractYAML(%s): %v", data, err)
+			}
+			if !Equal(got, c) {
+				t.Errorf("round trip of %s = %s", c, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalContractJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			"unknown field",
+			`{"scale": {"notional": 2, "contract": {"zcb": {"currency": "USD", "maturity": "2030-01-01", "notional": 1}}}}`,
+			`contract.scale.contract.zcb: unknown field "notional"`,
+		},
+		{
+			"unknown contract tag",
+			`{"combine": {"left": {"zero": {}}, "right": {"swap": {}}}}`,
+			`contract.combine.right: unknown contract type "swap"`,
+		},
+		{
+			"unknown observable tag",
+			`{"when": {"condition": {"coin_flip": {}}, "contract": {"zero": {}}}}`,
+			`contract.when.condition: unknown observable type "coin_flip"`,
+		},
+		{
+			"bad date",
+			`{"named": {"name": "x", "contract": {"forward": {"fixed_rate": 1.1, "maturity": "2030-13-01", "domestic": "USD", "foreign": "EUR"}}}}`,
+			`contract.named.contract.forward.maturity: invalid date "2030-13-01"`,
+		},
+		{
+			"unknown currency",
+			`{"spot": {"domestic": "USD", "foreign": "XXX"}}`,
+			`contract.spot.foreign: unknown currency`,
+		},
+		{
+			"missing field",
+			`{"zcb": {"currency": "USD"}}`,
+			`contract.zcb.maturity: missing field`,
+		},
+		{
+			"two tags",
+			`{"zero": {}, "spot": {}}`,
+			`contract: expected exactly one type key, got 2`,
+		},
+		{
+			"numeric condition",
+			`{"when": {"condition": {"const_double": {"value": 1}}, "contract": {"zero": {}}}}`,
+			`contract.when.condition: expected a boolean observable`,
+		},
+		{
+			"trailing data",
+			`{"zero": {}} {"zero": {}}`,
+			`unexpected data after contract`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalContractJSON([]byte(tt.json))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalContractYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{
+			"unknown field",
+			"when:\n  condition:\n    barrier: {direction: UP, level: 1.2, underlying: {spot_rate: {domestic: USD, foreign: EUR, pair: EURUSD}}}\n  contract: {zero: {}}\n",
+			`contract.when.condition.barrier.underlying.spot_rate: unknown field "pair"`,
+		},
+		{
+			"unknown tag",
+			"scale: {notional: 2, contract: {digital: {}}}\n",
+			`contract.scale.contract: unknown contract type "digital"`,
+		},
+		{
+			"bad date",
+			"zcb: {currency: USD, maturity: 31/12/2030}\n",
+			`contract.zcb.maturity: invalid date "31/12/2030"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalContractYAML([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Equal reports whether two contracts are structurally identical: same node
+// types, same fields and same children in the same order. Dates are compared
+// as UTC calendar dates, matching the serialized form.
+func Equal(a, b Contract) bool {
+	// Folding a yields a predicate matching trees identical to a
+	matches, err := Fold[matcher](a, equalVisitor{})
+	if err != nil {
+		return false
+	}
+	return matches(b)
+}
+
+// equalVisitor folds a contract into a predicate that matches a second tree
+// node by node
+type equalVisitor struct{}
+
+type matcher = func(Contract) bool
+
+func (equalVisitor) VisitNil(string) (matcher, error) {
+	return func(b Contract) bool { return b == nil }, nil
+}
+
+func (equalVisitor) VisitZero(string, Zero) (matcher, error) {
+	return func(c Contract) bool {
+		_, ok := c.(Zero)
+		return ok
+	}, nil
+}
+
+func (equalVisitor) VisitSpot(_ string, a Spot) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(Spot)
+		return ok && a.Domestic == b.Domestic && a.Foreign == b.Foreign
+	}, nil
+}
+
+func (equalVisitor) VisitForward(_ string, a Forward) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(Forward)
+		return ok && sameDate(a.Maturity, b.Maturity) && a.FixedRate == b.FixedRate &&
+			a.Domestic == b.Domestic && a.Foreign == b.Foreign
+	}, nil
+}
+
+func (equalVisitor) VisitEurOption(_ string, a EurOption) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(EurOption)
+		return ok && a.Type == b.Type && a.Strike == b.Strike && sameDate(a.Maturity, b.Maturity) &&
+			a.Domestic == b.Domestic && a.Foreign == b.Foreign
+	}, nil
+}
+
+func (equalVisitor) VisitZCB(_ string, a ZCB) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(ZCB)
+		return ok && a.Currency == b.Currency && sameDate(a.Maturity, b.Maturity)
+	}, nil
+}
+
+func (equalVisitor) VisitScale(_ string, a Scale, contract matcher) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(Scale)
+		return ok && a.Notional == b.Notional && contract(b.Contract)
+	}, nil
+}
+
+func (equalVisitor) VisitCombine(_ string, _ Combine, left, right matcher) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(Combine)
+		return ok && left(b.Left) && right(b.Right)
+	}, nil
+}
+
+func (equalVisitor) VisitWhen(_ string, a When, contract matcher) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(When)
+		return ok && EqualObservable(a.Condition, b.Condition) && contract(b.Contract)
+	}, nil
+}
+
+func (equalVisitor) VisitNamed(_ string, a Named, contract matcher) (matcher, error) {
+	return func(c Contract) bool {
+		b, ok := c.(Named)
+		return ok && a.Name == b.Name && contract(b.Contract)
+	}, nil
+}
+
+// EqualObservable reports whether two observables are structurally identical
+func EqualObservable(a, b Observable) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+
+	case ConstBool:
+		b, ok := b.(ConstBool)
+		return ok && a.Value == b.Value
+
+	case ConstDouble:
+		b, ok := b.(ConstDouble)
+		return ok && a.Value == b.Value
+
+	case SpotRate:
+		b, ok := b.(SpotRate)
+		return ok && a.Domestic == b.Domestic && a.Foreign == b.Foreign
+
+	case FwdRate:
+		b, ok := b.(FwdRate)
+		return ok && a.Domestic == b.Domestic && a.Foreign == b.Foreign && sameDate(a.Maturity, b.Maturity)
+
+	case Barrier:
+		b, ok := b.(Barrier)
+		return ok && a.Direction == b.Direction && a.Level == b.Level && EqualObservable(a.Underlying, b.Underlying)
+
+	default:
+		return false
+	}
+}
+
+// Equivalent reports whether two contracts represent the same portfolio,
+// regardless of Combine ordering, nesting of Scales or product names. It
+// fails if either contract cannot be normalized.
+func Equivalent(a, b Contract) (bool, error) {
+	na, err := Normalize(a)
+	if err != nil {
+		return false, err
+	}
+	nb, err := Normalize(b)
+	if err != nil {
+		return false, err
+	}
+	return Equal(na, nb), nil
+}
+
+// CanonicalEncoding returns the deterministic encoding of a contract's
+// normalized form. Equivalent contracts have identical encodings.
+func CanonicalEncoding(c Contract) ([]byte, error) {
+	normalized, err := Normalize(c)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalContractJSON(normalized)
+}
+
+// ID returns a stable content identifier for a contract: the hex SHA-256 of
+// its canonical encoding. It is suitable as a cache or audit key.
+func ID(c Contract) (string, error) {
+	data, err := CanonicalEncoding(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute contract ID: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sameDate compares the UTC calendar dates of two times, so the same instant
+// in different zones is the same date
+func sameDate(a, b time.Time) bool {
+	return a.UTC().Format(dateLayout) == b.UTC().Format(dateLayout)
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIDIsStableUnderCombineReordering(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	call := NewCallOption(1.1, maturity, USD, EUR)
+	put := NewScale(-1, NewPutOption(1.0, maturity, USD, EUR))
+	zcb := NewScale(1e6, NewZCB(USD, maturity))
+
+	base, err := ID(NewCombine(NewCombine(call, put), zcb))
+	if err != nil {
+		t.Fatalf("ID: %v", err)
+	}
+
+	same := map[string]Contract{
+		"reassociated": NewCombine(call, NewCombine(put, zcb)),
+		"reordered":    NewCombine(zcb, NewCombine(put, call)),
+		"named":        NewNamed("risk_reversal", NewCombine(NewCombine(call, put), zcb)),
+		"split scale":  NewCombine(NewCombine(call, put), NewCombine(NewScale(4e5, NewZCB(USD, maturity)), NewScale(6e5, NewZCB(USD, maturity)))),
+	}
+	for name, c := range same {
+		id, err := ID(c)
+		if err != nil {
+			t.Fatalf("%s: ID: %v", name, err)
+		}
+		if id != base {
+			t.Errorf("%s: ID = %s, want %s", name, id, base)
+		}
+	}
+
+	different := map[string]Contract{
+		"other strike":   NewCombine(NewCombine(NewCallOption(1.2, maturity, USD, EUR), put), zcb),
+		"other notional": NewCombine(NewCombine(call, put), NewScale(2e6, NewZCB(USD, maturity))),
+		"missing leg":    NewCombine(call, put),
+		"other maturity": NewCombine(NewCombine(call, put), NewScale(1e6, NewZCB(USD, maturity.AddDate(0, 0, 1)))),
+	}
+	for name, c := range different {
+		id, err := ID(c)
+		if err != nil {
+			t.Fatalf("%s: ID: %v", name, err)
+		}
+		if id == base {
+			t.Errorf("%s: ID matches the original contract", name)
+		}
+	}
+}
+
+func TestEqualComparesUTCDates(t *testing.T) {
+	// 23:00 in New York on 31 Dec is already 1 Jan in UTC
+	newYork := time.FixedZone("EST", -5*60*60)
+	local := time.Date(2029, 12, 31, 23, 0, 0, 0, newYork)
+	utc := time.Date(2030, 1, 1, 4, 0, 0, 0, time.UTC)
+
+	if !Equal(NewZCB(USD, local), NewZCB(USD, utc)) {
+		t.Error("the same instant in different zones should be the same date")
+	}
+	if Equal(NewZCB(USD, local), NewZCB(USD, time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC))) {
+		t.Error("the local calendar date should not be compared")
+	}
+
+	localID, err := ID(NewZCB(USD, local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	utcID, err := ID(NewZCB(USD, utc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if localID != utcID {
+		t.Errorf("ID differs across zones: %s vs %s", localID, utcID)
+	}
+}
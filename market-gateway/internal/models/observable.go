@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Direction represents the crossing direction of a barrier
+type Direction int
+
+const (
+	Up Direction = iota
+	Down
+)
+
+func (d Direction) String() string {
+	if d == Up {
+		return "UP"
+	}
+	return "DOWN"
+}
+
+// ParseDirection parses a barrier direction string ("UP" or "DOWN")
+func ParseDirection(s string) (Direction, error) {
+	switch s {
+	case "UP":
+		return Up, nil
+	case "DOWN":
+		return Down, nil
+	}
+	return 0, fmt.Errorf("unknown direction: %s", s)
+}
+
+// Observable is a marker interface for all observable types
+// This maps to the Haskell Observable GADT via protobuf
+type Observable interface {
+	isObservable()
+	String() string
+}
+
+// BoolObservable is an observable that evaluates to a boolean (Observable Bool)
+type BoolObservable interface {
+	Observable
+	isBoolObservable()
+}
+
+// DoubleObservable is an observable that evaluates to a number (Observable Double)
+type DoubleObservable interface {
+	Observable
+	isDoubleObservable()
+}
+
+// ConstBool represents a constant boolean observable
+type ConstBool struct {
+	Value bool
+}
+
+func (ConstBool) isObservable()     {}
+func (ConstBool) isBoolObservable() {}
+func (c ConstBool) String() string {
+	return fmt.Sprintf("Const(%t)", c.Value)
+}
+
+// ConstDouble represents a constant numeric observable
+type ConstDouble struct {
+	Value float64
+}
+
+func (ConstDouble) isObservable()       {}
+func (ConstDouble) isDoubleObservable() {}
+func (c ConstDouble) String() string {
+	return fmt.Sprintf("Const(%.4f)", c.Value)
+}
+
+// SpotRate observes the current spot rate between two currencies
+type SpotRate struct {
+	Domestic Currency
+	Foreign  Currency
+}
+
+func (SpotRate) isObservable()       {}
+func (SpotRate) isDoubleObservable() {}
+func (s SpotRate) String() string {
+	return fmt.Sprintf("SpotRate(%s/%s)", s.Foreign, s.Domestic)
+}
+
+// FwdRate observes the forward rate between two currencies at a future date
+type FwdRate struct {
+	Domestic Currency
+	Foreign  Currency
+	Maturity time.Time
+}
+
+func (FwdRate) isObservable()       {}
+func (FwdRate) isDoubleObservable() {}
+func (f FwdRate) String() string {
+	return fmt.Sprintf("FwdRate(%s/%s, Maturity: %s)",
+		f.Foreign, f.Domestic, f.Maturity.Format("2006-01-02"))
+}
+
+// Barrier is true once the underlying observable crosses the level
+type Barrier struct {
+	Direction  Direction
+	Level      float64
+	Underlying DoubleObservable
+}
+
+func (Barrier) isObservable()     {}
+func (Barrier) isBoolObservable() {}
+func (b Barrier) String() string {
+	return fmt.Sprintf("Barrier(%s, Level: %.4f, %s)", b.Direction, b.Level, b.Underlying)
+}
+
+// Builder functions for observables
+
+// NewConstBool creates a constant boolean observable
+func NewConstBool(value bool) ConstBool {
+	return ConstBool{Value: value}
+}
+
+// NewConstDouble creates a constant numeric observable
+func NewConstDouble(value float64) ConstDouble {
+	return ConstDouble{Value: value}
+}
+
+// NewSpotRate creates a spot rate observable
+func NewSpotRate(domestic, foreign Currency) SpotRate {
+	return SpotRate{
+		Domestic: domestic,
+		Foreign:  foreign,
+	}
+}
+
+// NewFwdRate creates a forward rate observable
+func NewFwdRate(domestic, foreign Currency, maturity time.Time) FwdRate {
+	return FwdRate{
+		Domestic: domestic,
+		Foreign:  foreign,
+		Maturity: maturity,
+	}
+}
+
+// NewBarrier creates a barrier condition on an underlying observable
+func NewBarrier(direction Direction, level float64, underlying DoubleObservable) Barrier {
+	return Barrier{
+		Direction:  direction,
+		Level:      level,
+		Underlying: underlying,
+	}
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CurrencyPair is an FX pair quoted as units of Quote per unit of Base, e.g.
+// EUR/USD = 1.10 means 1 EUR costs 1.10 USD. In contract terms the base is the
+// foreign currency and the quote the domestic one.
+type CurrencyPair struct {
+	Base  Currency
+	Quote Currency
+}
+
+// marketPriority ranks currencies for the market-standard quoting order;
+// currencies not listed rank below all of these
+var marketPriority = map[string]int{
+	"EUR": 0,
+	"GBP": 1,
+	"AUD": 2,
+	"NZD": 3,
+	"USD": 4,
+	"CAD": 5,
+	"CHF": 6,
+	"JPY": 7,
+}
+
+// NewCurrencyPair creates a pair quoted as quote per base
+func NewCurrencyPair(base, quote Currency) CurrencyPair {
+	return CurrencyPair{Base: base, Quote: quote}
+}
+
+// MarketPair returns the pair of two currencies in market-standard order,
+// e.g. MarketPair(USD, EUR) is EUR/USD and MarketPair(JPY, USD) is USD/JPY
+func MarketPair(a, b Currency) CurrencyPair {
+	return NewCurrencyPair(a, b).MarketConvention()
+}
+
+// ParseCurrencyPair parses "EUR/USD" or "EURUSD"
+func ParseCurrencyPair(s string) (CurrencyPair, error) {
+	base, quote, found := strings.Cut(s, "/")
+	if !found {
+		if len(s) != 6 {
+			return CurrencyPair{}, fmt.Errorf("invalid currency pair %q: expected CCY1/CCY2", s)
+		}
+		base, quote = s[:3], s[3:]
+	}
+
+	b, err := ParseCurrency(base)
+	if err != nil {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q: %w", s, err)
+	}
+	q, err := ParseCurrency(quote)
+	if err != nil {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q: %w", s, err)
+	}
+	if b == q {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q: currencies must differ", s)
+	}
+
+	return NewCurrencyPair(b, q), nil
+}
+
+func (p CurrencyPair) String() string {
+	return fmt.Sprintf("%s/%s", p.Base, p.Quote)
+}
+
+// Invert returns the pair with base and quote swapped
+func (p CurrencyPair) Invert() CurrencyPair {
+	return CurrencyPair{Base: p.Quote, Quote: p.Base}
+}
+
+// IsMarketConvention reports whether the pair is quoted in market-standard
+// order: EUR > GBP > AUD > NZD > USD > CAD > CHF > JPY > others, with others
+// ordered alphabetically
+func (p CurrencyPair) IsMarketConvention() bool {
+	base, quote := p.Base.String(), p.Quote.String()
+	basePriority, baseListed := marketPriority[base]
+	quotePriority, quoteListed := marketPriority[quote]
+
+	switch {
+	case baseListed && quoteListed:
+		return basePriority < quotePriority
+	case baseListed != quoteListed:
+		return baseListed
+	default:
+		return base < quote
+	}
+}
+
+// MarketConvention returns the pair in market-standard order
+func (p CurrencyPair) MarketConvention() CurrencyPair {
+	if p.IsMarketConvention() {
+		return p
+	}
+	return p.Invert()
+}
+
+// PipSize returns the smallest conventional quote increment: 0.0001 for most
+// pairs and 0.01 when the quote currency has no minor units (e.g. USD/JPY)
+func (p CurrencyPair) PipSize() float64 {
+	minorUnits := 2
+	if info, ok := p.Quote.Info(); ok {
+		minorUnits = info.MinorUnits
+	}
+	return math.Pow(10, -float64(minorUnits+2))
+}
+
+// ForwardPointScale returns the multiplier from an outright difference to
+// forward points, the reciprocal of the pip size (10000 for EUR/USD)
+func (p CurrencyPair) ForwardPointScale() float64 {
+	return 1 / p.PipSize()
+}
+
+// ForwardPoints converts a spot and outright forward into forward points
+func (p CurrencyPair) ForwardPoints(spot, forward float64) float64 {
+	return (forward - spot) * p.ForwardPointScale()
+}
+
+// OutrightForward converts a spot and forward points into an outright forward
+func (p CurrencyPair) OutrightForward(spot, points float64) float64 {
+	return spot + points/p.ForwardPointScale()
+}
+
+// Pair returns the pair a spot exchanges, foreign/domestic
+func (s Spot) Pair() CurrencyPair { return NewCurrencyPair(s.Foreign, s.Domestic) }
+
+// Pair returns the pair a forward exchanges, foreign/domestic
+func (f Forward) Pair() CurrencyPair { return NewCurrencyPair(f.Foreign, f.Domestic) }
+
+// Pair returns the option's underlying pair, foreign/domestic
+func (e EurOption) Pair() CurrencyPair { return NewCurrencyPair(e.Foreign, e.Domestic) }
+
+// Pair returns the observed pair, foreign/domestic
+func (s SpotRate) Pair() CurrencyPair { return NewCurrencyPair(s.Foreign, s.Domestic) }
+
+// Pair returns the observed pair, foreign/domestic
+func (f FwdRate) Pair() CurrencyPair { return NewCurrencyPair(f.Foreign, f.Domestic) }
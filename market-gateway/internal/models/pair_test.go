@@ -0,0 +1,115 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func mustCurrency(t *testing.T, code string) Currency {
+	t.Helper()
+	c, err := ParseCurrency(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestMarketPairOrdering(t *testing.T) {
+	// Every listed currency quotes against every one ranked below it
+	order := []string{"EUR", "GBP", "AUD", "NZD", "USD", "CAD", "CHF", "JPY"}
+	for i, base := range order {
+		for _, quote := range order[i+1:] {
+			want := base + "/" + quote
+			a, b := mustCurrency(t, base), mustCurrency(t, quote)
+			if got := MarketPair(a, b).String(); got != want {
+				t.Errorf("MarketPair(%s, %s) = %s, want %s", base, quote, got, want)
+			}
+			if got := MarketPair(b, a).String(); got != want {
+				t.Errorf("MarketPair(%s, %s) = %s, want %s", quote, base, got, want)
+			}
+		}
+	}
+}
+
+func TestMarketPairOutsideTheList(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"USD", "NOK", "USD/NOK"},
+		{"NOK", "JPY", "JPY/NOK"},
+		{"SEK", "EUR", "EUR/SEK"},
+		// Neither listed: alphabetical
+		{"SEK", "NOK", "NOK/SEK"},
+		{"NOK", "SEK", "NOK/SEK"},
+	}
+	for _, tt := range tests {
+		got := MarketPair(mustCurrency(t, tt.a), mustCurrency(t, tt.b))
+		if got.String() != tt.want {
+			t.Errorf("MarketPair(%s, %s) = %s, want %s", tt.a, tt.b, got, tt.want)
+		}
+		if !got.IsMarketConvention() || got.Invert().IsMarketConvention() {
+			t.Errorf("%s should be market convention and its inverse not", got)
+		}
+	}
+}
+
+func TestParseCurrencyPair(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    CurrencyPair
+		wantErr string
+	}{
+		{in: "EUR/USD", want: NewCurrencyPair(EUR, USD)},
+		{in: "USDJPY", want: NewCurrencyPair(USD, JPY)},
+		{in: "gbp/chf", want: NewCurrencyPair(GBP, CHF)},
+		{in: "EURUS", wantErr: "expected CCY1/CCY2"},
+		{in: "EUR/XXX", wantErr: "unknown currency: XXX"},
+		{in: "USD/USD", wantErr: "currencies must differ"},
+	}
+	for _, tt := range tests {
+		got, err := ParseCurrencyPair(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseCurrencyPair(%q) error = %v, want one containing %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseCurrencyPair(%q) = %s, %v, want %s", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestPipSizeAndForwardPoints(t *testing.T) {
+	tests := []struct {
+		pair    CurrencyPair
+		pip     float64
+		spot    float64
+		forward float64
+		points  float64
+	}{
+		{NewCurrencyPair(EUR, USD), 0.0001, 1.1000, 1.1025, 25},
+		{NewCurrencyPair(USD, JPY), 0.01, 150.00, 148.75, -125},
+		{NewCurrencyPair(EUR, JPY), 0.01, 165.00, 165.40, 40},
+		// The pip follows the quote currency, not the base
+		{NewCurrencyPair(JPY, USD), 0.0001, 0.006667, 0.006700, 0.33},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pair.String(), func(t *testing.T) {
+			if got := tt.pair.PipSize(); math.Abs(got-tt.pip) > 1e-15 {
+				t.Errorf("PipSize = %g, want %g", got, tt.pip)
+			}
+			if got := tt.pair.ForwardPointScale(); math.Abs(got*tt.pip-1) > 1e-12 {
+				t.Errorf("ForwardPointScale = %g, want %g", got, 1/tt.pip)
+			}
+			if got := tt.pair.ForwardPoints(tt.spot, tt.forward); math.Abs(got-tt.points) > 1e-6 {
+				t.Errorf("ForwardPoints = %g, want %g", got, tt.points)
+			}
+			if got := tt.pair.OutrightForward(tt.spot, tt.points); math.Abs(got-tt.forward) > 1e-12 {
+				t.Errorf("OutrightForward = %g, want %g", got, tt.forward)
+			}
+		})
+	}
+}
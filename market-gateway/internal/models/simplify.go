@@ -0,0 +1,213 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Leg is a single scaled position in a normalized portfolio
+type Leg struct {
+	Notional float64
+	Contract Contract
+	key      string // canonical encoding of Contract, used for sorting and netting
+}
+
+// Simplify applies the local algebraic laws from the PRD bottom-up while
+// preserving the shape of the tree:
+//
+//	Combine Zero c = c, Combine c Zero = c
+//	Scale 0 c = Zero, Scale α Zero = Zero, Scale 1 c = c
+//	Scale α (Scale β c) = Scale (α*β) c
+//
+// When and Named nodes whose contract simplifies to Zero become Zero. It
+// fails on nil nodes.
+func Simplify(c Contract) (Contract, error) {
+	return Transform(c, simplifyNode)
+}
+
+// simplifyNode applies the laws at a node whose children are already
+// simplified. Leaves have no laws and are kept.
+func simplifyNode(_ string, c Contract) (Contract, error) {
+	switch c := c.(type) {
+	case Scale:
+		return simplifyScale(c.Notional, c.Contract), nil
+
+	case Combine:
+		if isZero(c.Left) {
+			return c.Right, nil
+		}
+		if isZero(c.Right) {
+			return c.Left, nil
+		}
+
+	case When:
+		if isZero(c.Contract) {
+			return Zero{}, nil
+		}
+
+	case Named:
+		if isZero(c.Contract) {
+			return Zero{}, nil
+		}
+	}
+	return c, nil
+}
+
+// Normalize rewrites a contract into canonical form: scales are distributed
+// over combines (Scale α (Combine a b) = Combine (Scale α a) (Scale α b)),
+// product names are dropped, identical legs are netted, dropping those that
+// cancel up to rounding, and the remaining legs are sorted and recombined
+// right-nested. Equivalent portfolios normalize to the same tree. It fails on nil nodes and on legs that cannot be encoded.
+func Normalize(c Contract) (Contract, error) {
+	legs, err := Flatten(c)
+	if err != nil {
+		return nil, err
+	}
+	return combineLegs(legs), nil
+}
+
+// combineLegs recombines netted legs into a right-nested portfolio
+func combineLegs(legs []Leg) Contract {
+	contracts := make([]Contract, len(legs))
+	for i, leg := range legs {
+		contracts[i] = simplifyScale(leg.Notional, leg.Contract)
+	}
+	return CombineAll(contracts...)
+}
+
+// Flatten returns the canonical, netted and sorted legs of a contract
+func Flatten(c Contract) ([]Leg, error) {
+	raw, err := Fold[[]Leg](c, legCollector{})
+	if err != nil {
+		return nil, err
+	}
+	return netLegs(raw), nil
+}
+
+// netTolerance is the relative size below which a netted notional counts as
+// zero: a leg nets out when its sum is within netTolerance of the total
+// absolute notional netted into it. It absorbs float rounding, so that
+// 0.1 x + 0.2 x - 0.3 x nets to nothing, while positions that differ by any
+// meaningful amount are kept.
+const netTolerance = 1e-12
+
+// netLegs sums legs with identical contracts, drops those that net to zero
+// within netTolerance and sorts the rest by canonical key
+func netLegs(raw []Leg) []Leg {
+	index := make(map[string]int, len(raw))
+	var legs []Leg
+	var gross []float64 // total absolute notional netted into each leg
+	for _, leg := range raw {
+		if i, ok := index[leg.key]; ok {
+			legs[i].Notional += leg.Notional
+			gross[i] += math.Abs(leg.Notional)
+			continue
+		}
+		index[leg.key] = len(legs)
+		legs = append(legs, leg)
+		gross = append(gross, math.Abs(leg.Notional))
+	}
+
+	netted := legs[:0]
+	for i, leg := range legs {
+		if math.Abs(leg.Notional) > netTolerance*gross[i] {
+			netted = append(netted, leg)
+		}
+	}
+
+	sort.SliceStable(netted, func(i, j int) bool {
+		return netted[i].key < netted[j].key
+	})
+	return netted
+}
+
+// CombineAll folds contracts into right-nested Combines, matching Haskell's
+// right-associative (<>). An empty list is Zero.
+func CombineAll(contracts ...Contract) Contract {
+	if len(contracts) == 0 {
+		return Zero{}
+	}
+
+	result := contracts[len(contracts)-1]
+	for i := len(contracts) - 2; i >= 0; i-- {
+		result = NewCombine(contracts[i], result)
+	}
+	return result
+}
+
+// legCollector folds a contract into its unnetted legs. Scales multiply the
+// notionals of their legs and names are dropped. A When is a single leg
+// guarding its normalized contract.
+type legCollector struct{}
+
+func (legCollector) VisitZero(string, Zero) ([]Leg, error) { return nil, nil }
+
+func (legCollector) VisitSpot(_ string, c Spot) ([]Leg, error) { return newLeg(c) }
+
+func (legCollector) VisitForward(_ string, c Forward) ([]Leg, error) { return newLeg(c) }
+
+func (legCollector) VisitEurOption(_ string, c EurOption) ([]Leg, error) { return newLeg(c) }
+
+func (legCollector) VisitZCB(_ string, c ZCB) ([]Leg, error) { return newLeg(c) }
+
+func (legCollector) VisitScale(_ string, c Scale, legs []Leg) ([]Leg, error) {
+	for i := range legs {
+		legs[i].Notional *= c.Notional
+	}
+	return legs, nil
+}
+
+func (legCollector) VisitCombine(_ string, _ Combine, left, right []Leg) ([]Leg, error) {
+	return append(left, right...), nil
+}
+
+func (legCollector) VisitWhen(_ string, c When, legs []Leg) ([]Leg, error) {
+	netted := netLegs(legs)
+	if len(netted) == 0 {
+		return nil, nil
+	}
+	return newLeg(NewWhen(c.Condition, combineLegs(netted)))
+}
+
+func (legCollector) VisitNamed(_ string, _ Named, legs []Leg) ([]Leg, error) {
+	return legs, nil
+}
+
+// newLeg returns a contract as a single leg of unit notional
+func newLeg(c Contract) ([]Leg, error) {
+	key, err := canonicalKey(c)
+	if err != nil {
+		return nil, err
+	}
+	return []Leg{{Notional: 1, Contract: c, key: key}}, nil
+}
+
+func simplifyScale(notional float64, inner Contract) Contract {
+	if notional == 0 || isZero(inner) {
+		return Zero{}
+	}
+	if s, ok := inner.(Scale); ok {
+		return simplifyScale(notional*s.Notional, s.Contract)
+	}
+	if notional == 1 {
+		return inner
+	}
+	return NewScale(notional, inner)
+}
+
+func isZero(c Contract) bool {
+	_, ok := c.(Zero)
+	return ok
+}
+
+// canonicalKey returns an exact, deterministic encoding of a contract. The
+// JSON encoding sorts object keys and prints floats at full precision; it
+// fails on values JSON cannot represent, such as NaN strikes.
+func canonicalKey(c Contract) (string, error) {
+	data, err := MarshalContractJSON(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode leg %s: %w", c, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSimplify(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	call := NewCallOption(1.1, maturity, USD, EUR)
+
+	got, err := Simplify(NewCombine(Zero{}, NewScale(2, NewScale(0.5, NewNamed("n", NewCombine(call, Zero{}))))))
+	if err != nil {
+		t.Fatalf("Simplify: %v", err)
+	}
+	if want := NewNamed("n", call); !Equal(got, want) {
+		t.Fatalf("Simplify = %s, want %s", got, want)
+	}
+}
+
+func TestFlattenNetsIdenticalLegs(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	call := NewCallOption(1.1, maturity, USD, EUR)
+
+	legs, err := Flatten(NewCombine(NewScale(2, call), NewNamed("leg", NewScale(-0.5, call))))
+	if err != nil {
+		t.Fatalf("Flatten: %v", err)
+	}
+	if len(legs) != 1 || legs[0].Notional != 1.5 {
+		t.Fatalf("legs = %+v, want one leg with notional 1.5", legs)
+	}
+}
+
+func TestFlattenKeepsLegsDifferingBeyondPrintedPrecision(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewCallOption(1.10001, maturity, USD, EUR)
+	b := NewCallOption(1.10002, maturity, USD, EUR)
+	if a.String() != b.String() {
+		t.Fatalf("test needs strikes that print alike, got %s and %s", a, b)
+	}
+
+	legs, err := Flatten(NewCombine(a, NewScale(-1, b)))
+	if err != nil {
+		t.Fatalf("Flatten: %v", err)
+	}
+	if len(legs) != 2 {
+		t.Fatalf("got %d legs, want 2", len(legs))
+	}
+}
+
+func TestFlattenRejectsUnencodableAndNilLegs(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	nan := NewCallOption(math.NaN(), maturity, USD, EUR)
+
+	tests := map[string]Contract{
+		"NaN strike": NewCombine(nan, NewScale(-1, nan)),
+		"nil child":  NewCombine(NewSpot(USD, EUR), nil),
+		"nil root":   nil,
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Flatten(c); err == nil {
+				t.Fatal("expected an error")
+			}
+			if _, err := Normalize(c); err == nil {
+				t.Fatal("expected an error from Normalize")
+			}
+		})
+	}
+}
+
+func TestFlattenNetsRoundingResidues(t *testing.T) {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	call := NewCallOption(1.1, maturity, USD, EUR)
+	zcb := NewZCB(USD, maturity)
+
+	// 0.1 + 0.2 - 0.3 is about 5.5e-17 in floating point
+	legs, err := Flatten(CombineAll(NewScale(0.1, call), NewScale(0.2, call), NewScale(-0.3, call), zcb))
+	if err != nil {
+		t.Fatalf("Flatten: %v", err)
+	}
+	if len(legs) != 1 || !Equal(legs[0].Contract, zcb) {
+		t.Fatalf("legs = %+v, want only the ZCB", legs)
+	}
+
+	// The tolerance is relative: small positions that do not cancel are kept
+	legs, err = Flatten(CombineAll(NewScale(1e-9, call), NewScale(2e-9, call), NewScale(-2.9e-9, call)))
+	if err != nil {
+		t.Fatalf("Flatten: %v", err)
+	}
+	if len(legs) != 1 || math.Abs(legs[0].Notional-1e-10) > 1e-22 {
+		t.Fatalf("legs = %+v, want one leg with notional 1e-10", legs)
+	}
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Violation is a single validation failure at a location in a contract tree
+type Violation struct {
+	Path    string // e.g. "Combine.Left.Scale.Contract"
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ValidationError aggregates every violation found in a contract tree
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		lines[i] = v.String()
+	}
+	return fmt.Sprintf("invalid contract (%d violations):\n  %s", len(e.Violations), strings.Join(lines, "\n  "))
+}
+
+// Validate walks a contract tree and reports every violation it finds as a
+// *ValidationError. Maturities must fall on or after valuationDate; pass the
+// zero time to skip that check.
+func Validate(c Contract, valuationDate time.Time) error {
+	found, err := Fold[violations](c, validator{valuationDate: valuationDate})
+	if err != nil {
+		return err
+	}
+
+	if len(found) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: found}
+}
+
+// violations lists a node's own violations before those of its children
+type violations []Violation
+
+func (vs *violations) addf(path, node, format string, args ...any) {
+	*vs = append(*vs, Violation{Path: nodePath(path, node), Message: fmt.Sprintf(format, args...)})
+}
+
+type validator struct {
+	valuationDate time.Time
+}
+
+func (v validator) VisitNil(path string) (violations, error) {
+	var vs violations
+	vs.addf(path, "Contract", "contract is nil")
+	return vs, nil
+}
+
+func (v validator) VisitZero(string, Zero) (violations, error) { return nil, nil }
+
+func (v validator) VisitSpot(path string, c Spot) (violations, error) {
+	var vs violations
+	v.pair(&vs, c.Domestic, c.Foreign, path, "Spot")
+	return vs, nil
+}
+
+func (v validator) VisitForward(path string, c Forward) (violations, error) {
+	var vs violations
+	v.pair(&vs, c.Domestic, c.Foreign, path, "Forward")
+	v.maturity(&vs, c.Maturity, path, "Forward")
+	if !(c.FixedRate > 0) || math.IsInf(c.FixedRate, 0) {
+		vs.addf(path, "Forward", "fixed rate must be positive, got %v", c.FixedRate)
+	}
+	return vs, nil
+}
+
+func (v validator) VisitEurOption(path string, c EurOption) (violations, error) {
+	var vs violations
+	v.pair(&vs, c.Domestic, c.Foreign, path, "EurOption")
+	v.maturity(&vs, c.Maturity, path, "EurOption")
+	if !(c.Strike > 0) || math.IsInf(c.Strike, 0) {
+		vs.addf(path, "EurOption", "strike must be positive, got %v", c.Strike)
+	}
+	if c.Type != Call && c.Type != Put {
+		vs.addf(path, "EurOption", "invalid option type %d", int(c.Type))
+	}
+	return vs, nil
+}
+
+func (v validator) VisitZCB(path string, c ZCB) (violations, error) {
+	var vs violations
+	v.currency(&vs, c.Currency, path, "ZCB", "currency")
+	v.maturity(&vs, c.Maturity, path, "ZCB")
+	return vs, nil
+}
+
+func (v validator) VisitScale(path string, c Scale, contract violations) (violations, error) {
+	var vs violations
+	if math.IsNaN(c.Notional) || math.IsInf(c.Notional, 0) {
+		vs.addf(path, "Scale", "notional must be finite, got %v", c.Notional)
+	}
+	return append(vs, contract...), nil
+}
+
+func (v validator) VisitCombine(_ string, _ Combine, left, right violations) (violations, error) {
+	return append(left, right...), nil
+}
+
+func (v validator) VisitWhen(path string, c When, contract violations) (violations, error) {
+	var vs violations
+	v.observable(&vs, c.Condition, ChildPath(path, "When", "Condition"))
+	return append(vs, contract...), nil
+}
+
+func (v validator) VisitNamed(path string, c Named, contract violations) (violations, error) {
+	var vs violations
+	if c.Name == "" {
+		vs.addf(path, "Named", "name must not be empty")
+	}
+	return append(vs, contract...), nil
+}
+
+func (v validator) observable(vs *violations, o Observable, path string) {
+	switch o := o.(type) {
+	case nil:
+		vs.addf(path, "Observable", "observable is nil")
+
+	case ConstBool:
+
+	case ConstDouble:
+		if math.IsNaN(o.Value) || math.IsInf(o.Value, 0) {
+			vs.addf(path, "Const", "value must be finite, got %v", o.Value)
+		}
+
+	case SpotRate:
+		v.pair(vs, o.Domestic, o.Foreign, path, "SpotRate")
+
+	case FwdRate:
+		v.pair(vs, o.Domestic, o.Foreign, path, "FwdRate")
+		v.maturity(vs, o.Maturity, path, "FwdRate")
+
+	case Barrier:
+		if !(o.Level > 0) || math.IsInf(o.Level, 0) {
+			vs.addf(path, "Barrier", "barrier level must be positive, got %v", o.Level)
+		}
+		if o.Direction != Up && o.Direction != Down {
+			vs.addf(path, "Barrier", "invalid direction %d", int(o.Direction))
+		}
+		v.observable(vs, o.Underlying, ChildPath(path, "Barrier", "Underlying"))
+
+	default:
+		vs.addf(path, "Observable", "unsupported observable type %T", o)
+	}
+}
+
+func (v validator) pair(vs *violations, domestic, foreign Currency, path, node string) {
+	domesticOK := v.currency(vs, domestic, path, node, "domestic")
+	foreignOK := v.currency(vs, foreign, path, node, "foreign")
+	if domesticOK && foreignOK && domestic == foreign {
+		vs.addf(path, node, "domestic and foreign currencies must differ, both are %s", domestic)
+	}
+}
+
+func (v validator) currency(vs *violations, c Currency, path, node, field string) bool {
+	if c.String() == "" {
+		vs.addf(path, node, "%s currency %d is not a known currency", field, int(c))
+		return false
+	}
+	return true
+}
+
+func (v validator) maturity(vs *violations, maturity time.Time, path, node string) {
+	if maturity.IsZero() {
+		vs.addf(path, node, "maturity is not set")
+		return
+	}
+	if !v.valuationDate.IsZero() && maturity.Format(dateLayout) < v.valuationDate.Format(dateLayout) {
+		vs.addf(path, node, "maturity %s is before valuation date %s",
+			maturity.Format(dateLayout), v.valuationDate.Format(dateLayout))
+	}
+}
@@ -0,0 +1,230 @@
+package models
+
+import "fmt"
+
+// Visitor is a fold algebra over contract trees: one method per node type.
+// Composite nodes receive the already folded results of their children.
+// Returning an error stops the fold.
+//
+// Every contract type dispatches itself to a dispatcher method through
+// Contract.accept, and the fold adapter forwards each dispatcher method to
+// the matching Visitor method. Adding a contract type therefore fails to
+// compile until it has a dispatcher method, and adding that method fails to
+// compile until Visitor and every visitor handle the new node.
+//
+// path locates the node in the tree in the same form as validation
+// violations, e.g. "Combine.Left.Scale.Contract"; the root has the empty path.
+type Visitor[R any] interface {
+	VisitZero(path string, c Zero) (R, error)
+	VisitSpot(path string, c Spot) (R, error)
+	VisitForward(path string, c Forward) (R, error)
+	VisitEurOption(path string, c EurOption) (R, error)
+	VisitZCB(path string, c ZCB) (R, error)
+	VisitScale(path string, c Scale, contract R) (R, error)
+	VisitCombine(path string, c Combine, left, right R) (R, error)
+	VisitWhen(path string, c When, contract R) (R, error)
+	VisitNamed(path string, c Named, contract R) (R, error)
+}
+
+// NilVisitor is implemented by visitors that handle nil nodes themselves,
+// e.g. to report them alongside other problems instead of failing the fold
+type NilVisitor[R any] interface {
+	VisitNil(path string) (R, error)
+}
+
+// Fold reduces a contract tree bottom-up with a visitor. It fails on nil
+// nodes, reporting their path, unless the visitor is a NilVisitor.
+func Fold[R any](c Contract, v Visitor[R]) (R, error) {
+	return fold(c, v, "")
+}
+
+func fold[R any](c Contract, v Visitor[R], path string) (R, error) {
+	if c == nil {
+		if nv, ok := v.(NilVisitor[R]); ok {
+			return nv.VisitNil(path)
+		}
+		var zero R
+		return zero, fmt.Errorf("%s: contract is nil", nodePath(path, "Contract"))
+	}
+
+	f := &folder[R]{visitor: v, path: path}
+	c.accept(f)
+	return f.result, f.err
+}
+
+// dispatcher has one method per contract type; Contract.accept calls the
+// method for the node's type
+type dispatcher interface {
+	zero(c Zero)
+	spot(c Spot)
+	forward(c Forward)
+	eurOption(c EurOption)
+	zcb(c ZCB)
+	scale(c Scale)
+	combine(c Combine)
+	when(c When)
+	named(c Named)
+}
+
+// folder adapts a Visitor to a dispatcher for a single node, folding its
+// children first
+type folder[R any] struct {
+	visitor Visitor[R]
+	path    string
+	result  R
+	err     error
+}
+
+func (f *folder[R]) zero(c Zero)           { f.result, f.err = f.visitor.VisitZero(f.path, c) }
+func (f *folder[R]) spot(c Spot)           { f.result, f.err = f.visitor.VisitSpot(f.path, c) }
+func (f *folder[R]) forward(c Forward)     { f.result, f.err = f.visitor.VisitForward(f.path, c) }
+func (f *folder[R]) eurOption(c EurOption) { f.result, f.err = f.visitor.VisitEurOption(f.path, c) }
+func (f *folder[R]) zcb(c ZCB)             { f.result, f.err = f.visitor.VisitZCB(f.path, c) }
+
+func (f *folder[R]) scale(c Scale) {
+	inner, err := fold(c.Contract, f.visitor, ChildPath(f.path, "Scale", "Contract"))
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.result, f.err = f.visitor.VisitScale(f.path, c, inner)
+}
+
+func (f *folder[R]) combine(c Combine) {
+	left, err := fold(c.Left, f.visitor, ChildPath(f.path, "Combine", "Left"))
+	if err != nil {
+		f.err = err
+		return
+	}
+	right, err := fold(c.Right, f.visitor, ChildPath(f.path, "Combine", "Right"))
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.result, f.err = f.visitor.VisitCombine(f.path, c, left, right)
+}
+
+func (f *folder[R]) when(c When) {
+	inner, err := fold(c.Contract, f.visitor, ChildPath(f.path, "When", "Contract"))
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.result, f.err = f.visitor.VisitWhen(f.path, c, inner)
+}
+
+func (f *folder[R]) named(c Named) {
+	inner, err := fold(c.Contract, f.visitor, ChildPath(f.path, "Named", "Contract"))
+	if err != nil {
+		f.err = err
+		return
+	}
+	f.result, f.err = f.visitor.VisitNamed(f.path, c, inner)
+}
+
+// Walk visits every node of a contract tree in pre-order. Returning false
+// from fn skips the children of that node. It fails on nil nodes.
+func Walk(c Contract, fn func(path string, c Contract) bool) error {
+	return walk(c, fn, "")
+}
+
+func walk(c Contract, fn func(path string, c Contract) bool, path string) error {
+	if c == nil {
+		return fmt.Errorf("%s: contract is nil", nodePath(path, "Contract"))
+	}
+	if !fn(path, c) {
+		return nil
+	}
+
+	lister := &childLister{path: path}
+	c.accept(lister)
+	for _, ch := range lister.children {
+		if err := walk(ch.contract, fn, ch.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type childContract struct {
+	path     string
+	contract Contract
+}
+
+// childLister collects the direct sub-contracts of a node with their paths
+type childLister struct {
+	path     string
+	children []childContract
+}
+
+func (l *childLister) add(node, field string, c Contract) {
+	l.children = append(l.children, childContract{ChildPath(l.path, node, field), c})
+}
+
+func (l *childLister) zero(Zero)           {}
+func (l *childLister) spot(Spot)           {}
+func (l *childLister) forward(Forward)     {}
+func (l *childLister) eurOption(EurOption) {}
+func (l *childLister) zcb(ZCB)             {}
+func (l *childLister) scale(c Scale)       { l.add("Scale", "Contract", c.Contract) }
+func (l *childLister) when(c When)         { l.add("When", "Contract", c.Contract) }
+func (l *childLister) named(c Named)       { l.add("Named", "Contract", c.Contract) }
+
+func (l *childLister) combine(c Combine) {
+	l.add("Combine", "Left", c.Left)
+	l.add("Combine", "Right", c.Right)
+}
+
+// Transform rebuilds a contract tree bottom-up: the children of each node are
+// transformed first, then fn is applied to the node holding the new children.
+// Returning the node unchanged keeps it. It fails on nil nodes.
+func Transform(c Contract, fn func(path string, c Contract) (Contract, error)) (Contract, error) {
+	return Fold[Contract](c, transformer(fn))
+}
+
+// transformer rebuilds each composite node around its transformed children
+// before handing it to the transform function
+type transformer func(path string, c Contract) (Contract, error)
+
+func (t transformer) VisitZero(path string, c Zero) (Contract, error) { return t(path, c) }
+func (t transformer) VisitSpot(path string, c Spot) (Contract, error) { return t(path, c) }
+func (t transformer) VisitForward(path string, c Forward) (Contract, error) {
+	return t(path, c)
+}
+func (t transformer) VisitEurOption(path string, c EurOption) (Contract, error) {
+	return t(path, c)
+}
+func (t transformer) VisitZCB(path string, c ZCB) (Contract, error) { return t(path, c) }
+
+func (t transformer) VisitScale(path string, c Scale, contract Contract) (Contract, error) {
+	return t(path, NewScale(c.Notional, contract))
+}
+
+func (t transformer) VisitCombine(path string, _ Combine, left, right Contract) (Contract, error) {
+	return t(path, NewCombine(left, right))
+}
+
+func (t transformer) VisitWhen(path string, c When, contract Contract) (Contract, error) {
+	return t(path, NewWhen(c.Condition, contract))
+}
+
+func (t transformer) VisitNamed(path string, c Named, contract Contract) (Contract, error) {
+	return t(path, NewNamed(c.Name, contract))
+}
+
+// ChildPath returns the path of a field of the node at path, e.g.
+// ChildPath("Combine.Left", "Scale", "Contract") is "Combine.Left.Scale.Contract"
+func ChildPath(path, node, field string) string {
+	if path == "" {
+		return node + "." + field
+	}
+	return path + "." + node + "." + field
+}
+
+// nodePath names the node at path in messages, using the node type at the root
+func nodePath(path, node string) string {
+	if path == "" {
+		return node
+	}
+	return path
+}
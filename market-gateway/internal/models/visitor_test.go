@@ -0,0 +1,85 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPortfolio() Contract {
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	return NewCombine(
+		NewScale(2, NewNamed("call", NewCallOption(1.1, maturity, USD, EUR))),
+		NewWhen(NewConstBool(true), NewZCB(USD, maturity)),
+	)
+}
+
+func TestWalkVisitsInPreOrderWithPaths(t *testing.T) {
+	var paths []string
+	err := Walk(testPortfolio(), func(path string, c Contract) bool {
+		paths = append(paths, path)
+		_, isNamed := c.(Named)
+		return !isNamed
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+
+	want := []string{"", "Combine.Left", "Combine.Left.Scale.Contract", "Combine.Right", "Combine.Right.When.Contract"}
+	if strings.Join(paths, "|") != strings.Join(want, "|") {
+		t.Fatalf("paths = %q, want %q", paths, want)
+	}
+}
+
+func TestTransformRebuildsBottomUp(t *testing.T) {
+	// Double every notional and strip names
+	got, err := Transform(testPortfolio(), func(_ string, c Contract) (Contract, error) {
+		switch c := c.(type) {
+		case Scale:
+			return NewScale(2*c.Notional, c.Contract), nil
+		case Named:
+			return c.Contract, nil
+		}
+		return c, nil
+	})
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+
+	maturity := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := NewCombine(
+		NewScale(4, NewCallOption(1.1, maturity, USD, EUR)),
+		NewWhen(NewConstBool(true), NewZCB(USD, maturity)),
+	)
+	if !Equal(got, want) {
+		t.Fatalf("Transform = %s, want %s", got, want)
+	}
+}
+
+func TestTraversalsRejectNilNodes(t *testing.T) {
+	c := NewCombine(NewSpot(USD, EUR), NewScale(1, nil))
+	const path = "Combine.Right.Scale.Contract"
+
+	if err := Walk(c, func(string, Contract) bool { return true }); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Walk error = %v, want one naming %s", err, path)
+	}
+	if _, err := Transform(c, func(_ string, c Contract) (Contract, error) { return c, nil }); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("Transform error = %v, want one naming %s", err, path)
+	}
+	if _, err := Simplify(c); err == nil {
+		t.Error("Simplify accepted a nil node")
+	}
+}
+
+func TestValidateReportsNilNodesWithOtherViolations(t *testing.T) {
+	c := NewCombine(NewScale(1, nil), NewSpot(USD, USD))
+
+	err := Validate(c, time.Time{})
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Validate error = %v, want *ValidationError", err)
+	}
+	if len(verr.Violations) != 2 || verr.Violations[0].Path != "Combine.Left.Scale.Contract" || verr.Violations[1].Path != "Combine.Right" {
+		t.Fatalf("violations = %v", verr.Violations)
+	}
+}
@@ -0,0 +1,153 @@
+package products
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+// Product names used to tag the contracts built in this package
+const (
+	StraddleName         = "straddle"
+	StrangleName         = "strangle"
+	RiskReversalName     = "risk_reversal"
+	CallSpreadName       = "call_spread"
+	PutSpreadName        = "put_spread"
+	ButterflyName        = "butterfly"
+	SeagullName          = "seagull"
+	ExamplePortfolioName = "example_portfolio"
+)
+
+// Straddle - long both call and put at the same strike
+func Straddle(strike float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(StraddleName, strike); err != nil {
+		return models.Named{}, err
+	}
+
+	return models.NewNamed(StraddleName, models.CombineAll(
+		models.NewCallOption(strike, maturity, domestic, foreign),
+		models.NewPutOption(strike, maturity, domestic, foreign),
+	)), nil
+}
+
+// Strangle - long put at the lower strike, long call at the higher strike
+func Strangle(putStrike, callStrike float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(StrangleName, putStrike, callStrike); err != nil {
+		return models.Named{}, err
+	}
+
+	return models.NewNamed(StrangleName, models.CombineAll(
+		models.NewPutOption(putStrike, maturity, domestic, foreign),
+		models.NewCallOption(callStrike, maturity, domestic, foreign),
+	)), nil
+}
+
+// RiskReversal - long call, short put
+// Used to express a directional view with reduced cost
+func RiskReversal(putStrike, callStrike float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(RiskReversalName, putStrike, callStrike); err != nil {
+		return models.Named{}, err
+	}
+
+	return models.NewNamed(RiskReversalName, models.CombineAll(
+		models.NewCallOption(callStrike, maturity, domestic, foreign),
+		models.NewScale(-1, models.NewPutOption(putStrike, maturity, domestic, foreign)),
+	)), nil
+}
+
+// CallSpread - long call at the lower strike, short call at the higher strike
+func CallSpread(lowerStrike, upperStrike float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(CallSpreadName, lowerStrike, upperStrike); err != nil {
+		return models.Named{}, err
+	}
+
+	return models.NewNamed(CallSpreadName, models.CombineAll(
+		models.NewCallOption(lowerStrike, maturity, domestic, foreign),
+		models.NewScale(-1, models.NewCallOption(upperStrike, maturity, domestic, foreign)),
+	)), nil
+}
+
+// PutSpread - long put at the higher strike, short put at the lower strike
+func PutSpread(upperStrike, lowerStrike float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(PutSpreadName, lowerStrike, upperStrike); err != nil {
+		return models.Named{}, err
+	}
+
+	return models.NewNamed(PutSpreadName, models.CombineAll(
+		models.NewPutOption(upperStrike, maturity, domestic, foreign),
+		models.NewScale(-1, models.NewPutOption(lowerStrike, maturity, domestic, foreign)),
+	)), nil
+}
+
+// Butterfly - long calls at the wing strikes, short two calls at the middle strike
+func Butterfly(lowerStrike, middleStrike, upperStrike float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(ButterflyName, lowerStrike, middleStrike, upperStrike); err != nil {
+		return models.Named{}, err
+	}
+
+	return models.NewNamed(ButterflyName, models.CombineAll(
+		models.NewCallOption(lowerStrike, maturity, domestic, foreign),
+		models.NewScale(-2, models.NewCallOption(middleStrike, maturity, domestic, foreign)),
+		models.NewCallOption(upperStrike, maturity, domestic, foreign),
+	)), nil
+}
+
+// Seagull - risk reversal whose long call knocks out at an upper barrier
+func Seagull(putStrike, callStrike, knockOutLevel float64, maturity time.Time, domestic, foreign models.Currency) (models.Named, error) {
+	if err := validateStrikes(SeagullName, putStrike, callStrike); err != nil {
+		return models.Named{}, err
+	}
+
+	knockOutCall, err := models.NewUpAndOutOption(knockOutLevel, models.Call, callStrike, maturity, domestic, foreign)
+	if err != nil {
+		return models.Named{}, fmt.Errorf("%s: %w", SeagullName, err)
+	}
+
+	return models.NewNamed(SeagullName, models.CombineAll(
+		knockOutCall,
+		models.NewScale(-1, models.NewPutOption(putStrike, maturity, domestic, foreign)),
+	)), nil
+}
+
+// ExamplePortfolio mirrors examplePortfolio in FX/Examples/Products.hs on EUR/USD
+func ExamplePortfolio(maturity time.Time) (models.Named, error) {
+	knockIn, err := models.NewUpAndInOption(1.15, models.Call, 1.10, maturity, models.USD, models.EUR)
+	if err != nil {
+		return models.Named{}, fmt.Errorf("%s: %w", ExamplePortfolioName, err)
+	}
+
+	return models.NewNamed(ExamplePortfolioName, models.CombineAll(
+		// Long 1M EUR/USD forward
+		models.NewScale(1_000_000, fxForward(maturity, models.USD, models.EUR)),
+		// Long 500K EUR call USD put, strike 1.10
+		models.NewScale(500_000, models.NewCallOption(1.10, maturity, models.USD, models.EUR)),
+		// Short 500K EUR call USD put, strike 1.20 (covered call)
+		models.NewScale(-500_000, models.NewCallOption(1.20, maturity, models.USD, models.EUR)),
+		// Long 250K knock-in option (activates if EUR/USD goes above 1.15)
+		models.NewScale(250_000, knockIn),
+	)), nil
+}
+
+// fxForward builds a forward via covered interest parity, as in Combinators.hs:
+// exchange spot, receive a foreign ZCB and pay a domestic ZCB
+func fxForward(maturity time.Time, domestic, foreign models.Currency) models.Contract {
+	return models.CombineAll(
+		models.NewSpot(domestic, foreign),
+		models.NewZCB(foreign, maturity),
+		models.NewScale(-1, models.NewZCB(domestic, maturity)),
+	)
+}
+
+// validateStrikes checks that strikes are positive and strictly increasing
+func validateStrikes(product string, strikes ...float64) error {
+	for i, strike := range strikes {
+		if strike <= 0 {
+			return fmt.Errorf("%s: invalid strike %f: must be positive", product, strike)
+		}
+		if i > 0 && strike <= strikes[i-1] {
+			return fmt.Errorf("%s: strikes must be strictly increasing, got %f after %f", product, strike, strikes[i-1])
+		}
+	}
+	return nil
+}
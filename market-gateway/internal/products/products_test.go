@@ -0,0 +1,109 @@
+package products
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leonc/ficc-pricer/market-gateway/internal/models"
+)
+
+var maturity = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestBuildersTagProducts(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() (models.Named, error)
+		want  models.Contract
+	}{
+		{StraddleName, func() (models.Named, error) { return Straddle(1.1, maturity, models.USD, models.EUR) },
+			models.CombineAll(
+				models.NewCallOption(1.1, maturity, models.USD, models.EUR),
+				models.NewPutOption(1.1, maturity, models.USD, models.EUR),
+			)},
+		{StrangleName, func() (models.Named, error) { return Strangle(1.0, 1.2, maturity, models.USD, models.EUR) },
+			models.CombineAll(
+				models.NewPutOption(1.0, maturity, models.USD, models.EUR),
+				models.NewCallOption(1.2, maturity, models.USD, models.EUR),
+			)},
+		{RiskReversalName, func() (models.Named, error) { return RiskReversal(1.0, 1.2, maturity, models.USD, models.EUR) },
+			models.CombineAll(
+				models.NewCallOption(1.2, maturity, models.USD, models.EUR),
+				models.NewScale(-1, models.NewPutOption(1.0, maturity, models.USD, models.EUR)),
+			)},
+		{CallSpreadName, func() (models.Named, error) { return CallSpread(1.0, 1.2, maturity, models.USD, models.EUR) },
+			models.CombineAll(
+				models.NewCallOption(1.0, maturity, models.USD, models.EUR),
+				models.NewScale(-1, models.NewCallOption(1.2, maturity, models.USD, models.EUR)),
+			)},
+		{PutSpreadName, func() (models.Named, error) { return PutSpread(1.2, 1.0, maturity, models.USD, models.EUR) },
+			models.CombineAll(
+				models.NewPutOption(1.2, maturity, models.USD, models.EUR),
+				models.NewScale(-1, models.NewPutOption(1.0, maturity, models.USD, models.EUR)),
+			)},
+		{ButterflyName, func() (models.Named, error) { return Butterfly(1.0, 1.1, 1.2, maturity, models.USD, models.EUR) },
+			models.CombineAll(
+				models.NewCallOption(1.0, maturity, models.USD, models.EUR),
+				models.NewScale(-2, models.NewCallOption(1.1, maturity, models.USD, models.EUR)),
+				models.NewCallOption(1.2, maturity, models.USD, models.EUR),
+			)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tt.name {
+				t.Errorf("name = %q, want %q", got.Name, tt.name)
+			}
+			if !models.Equal(got.Contract, tt.want) {
+				t.Errorf("contract = %s, want %s", got.Contract, tt.want)
+			}
+		})
+	}
+
+	seagull, err := Seagull(1.0, 1.1, 1.3, maturity, models.USD, models.EUR)
+	if err != nil || seagull.Name != SeagullName {
+		t.Errorf("Seagull = %q, %v, want %q", seagull.Name, err, SeagullName)
+	}
+	portfolio, err := ExamplePortfolio(maturity)
+	if err != nil || portfolio.Name != ExamplePortfolioName {
+		t.Errorf("ExamplePortfolio = %q, %v, want %q", portfolio.Name, err, ExamplePortfolioName)
+	}
+}
+
+func TestBuildersRejectStrikeOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() (models.Named, error)
+		want  string
+	}{
+		{"straddle non-positive", func() (models.Named, error) { return Straddle(0, maturity, models.USD, models.EUR) },
+			"straddle: invalid strike 0.000000: must be positive"},
+		{"strangle", func() (models.Named, error) { return Strangle(1.2, 1.0, maturity, models.USD, models.EUR) },
+			"strangle: strikes must be strictly increasing, got 1.000000 after 1.200000"},
+		{"risk reversal", func() (models.Named, error) { return RiskReversal(1.2, 1.2, maturity, models.USD, models.EUR) },
+			"risk_reversal: strikes must be strictly increasing, got 1.200000 after 1.200000"},
+		{"call spread", func() (models.Named, error) { return CallSpread(1.2, 1.0, maturity, models.USD, models.EUR) },
+			"call_spread: strikes must be strictly increasing, got 1.000000 after 1.200000"},
+		{"put spread", func() (models.Named, error) { return PutSpread(1.0, 1.2, maturity, models.USD, models.EUR) },
+			"put_spread: strikes must be strictly increasing, got 1.000000 after 1.200000"},
+		{"butterfly", func() (models.Named, error) { return Butterfly(1.0, 1.2, 1.1, maturity, models.USD, models.EUR) },
+			"butterfly: strikes must be strictly increasing, got 1.100000 after 1.200000"},
+		{"butterfly non-positive", func() (models.Named, error) { return Butterfly(-1, 1.1, 1.2, maturity, models.USD, models.EUR) },
+			"butterfly: invalid strike -1.000000: must be positive"},
+		{"seagull", func() (models.Named, error) { return Seagull(1.1, 1.0, 1.3, maturity, models.USD, models.EUR) },
+			"seagull: strikes must be strictly increasing, got 1.000000 after 1.100000"},
+		{"seagull knock-out below call strike", func() (models.Named, error) { return Seagull(1.0, 1.1, 1.05, maturity, models.USD, models.EUR) },
+			"seagull: invalid up barrier 1.050000 for call with strike 1.100000: barrier must be above strike"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}